@@ -49,6 +49,14 @@ func init() {
 	stakingCommand.AddToParent(Cmd)
 	getCommand.AddToParent(Cmd)
 	fundCommand.AddToParent(Cmd)
+	transactionsCommand.AddToParent(Cmd)
+	addKeyCommand.AddToParent(Cmd)
+	revokeKeyCommand.AddToParent(Cmd)
+	rotateKeyCommand.AddToParent(Cmd)
+	storageCommand.AddToParent(Cmd)
+	assetsCommand.AddToParent(Cmd)
+	diffCommand.AddToParent(Cmd)
+	exportContractsCommand.AddToParent(Cmd)
 }
 
 // accountResult represent result from all account commands.
@@ -20,11 +20,16 @@ package accounts
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/config"
+	"github.com/onflow/flowkit/v2/transactions"
 
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
@@ -267,6 +272,592 @@ func Test_Get(t *testing.T) {
 	})
 }
 
+func Test_Transactions(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+	address := flow.HexToAddress("0x01")
+	other := flow.HexToAddress("0x02")
+
+	payerTx := tests.NewTransaction().SetPayer(address).SetProposalKey(other, 0, 0)
+	authorizerTx := tests.NewTransaction().SetPayer(other).SetProposalKey(other, 0, 1).AddAuthorizer(address)
+	unrelatedTx := tests.NewTransaction().SetPayer(other).SetProposalKey(other, 0, 2)
+	txs := map[flow.Identifier]*flow.Transaction{
+		payerTx.ID():      payerTx,
+		authorizerTx.ID(): authorizerTx,
+		unrelatedTx.ID():  unrelatedTx,
+	}
+	collections := map[uint64][]flow.Identifier{
+		9:  {payerTx.ID(), unrelatedTx.ID()},
+		10: {authorizerTx.ID()},
+	}
+
+	srv.GetBlock.Run(func(args mock.Arguments) {
+		query := args.Get(1).(flowkit.BlockQuery)
+		block := tests.NewBlock()
+		block.Height = 10
+		if !query.Latest {
+			block.Height = query.Height
+		}
+		block.CollectionGuarantees = []*flow.CollectionGuarantee{{CollectionID: flow.Identifier{byte(block.Height)}}}
+		srv.GetBlock.Return(block, nil)
+	})
+	srv.GetCollection.Run(func(args mock.Arguments) {
+		id := args.Get(1).(flow.Identifier)
+		srv.GetCollection.Return(&flow.Collection{TransactionIDs: collections[uint64(id[0])]}, nil)
+	})
+	srv.GetTransactionByID.Run(func(args mock.Arguments) {
+		id := args.Get(1).(flow.Identifier)
+		srv.GetTransactionByID.Return(txs[id], &flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		transactionsFlags.Last = 2
+		transactionsFlags.Workers = 1
+
+		result, err := listTransactions([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, nil, srv.Mock)
+		require.NoError(t, err)
+
+		found := result.(*accountTransactionsResult).transactions
+		require.Len(t, found, 2)
+		assert.Equal(t, authorizerTx.ID(), found[0].tx.ID())
+		assert.Equal(t, []string{roleAuthorizer}, found[0].roles)
+		assert.Equal(t, payerTx.ID(), found[1].tx.ID())
+		assert.Equal(t, []string{rolePayer}, found[1].roles)
+		assert.Contains(t, result.String(), "Roles\tauthorizer")
+	})
+
+	t.Run("Fail invalid range", func(t *testing.T) {
+		transactionsFlags.Start = 10
+
+		_, err := listTransactions([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, nil, srv.Mock)
+		assert.EqualError(t, err, "please provide either both start and end for range or only last flag")
+	})
+
+	transactionsFlags = flagsTransactions{Last: 10, Workers: 10}
+}
+
+func Test_AddKey(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	pkey := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
+
+	t.Run("Success", func(t *testing.T) {
+		addKeyFlags.Key = pkey
+		addKeyFlags.Weight = 500
+		addKeyFlags.HashAlgo = "SHA2_256"
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			script := args.Get(2).(flowkit.Script)
+			assert.Equal(t, "emulator-account", roles.Payer.Name)
+			require.Len(t, script.Args, 4)
+			assert.Equal(t, cadence.String(pkey), script.Args[0])
+			assert.Equal(t, cadence.UInt8(1), script.Args[1])
+			assert.Equal(t, cadence.UInt8(1), script.Args[2])
+			assert.Equal(t, "500.00000000", script.Args[3].String())
+		}).Return(tests.NewTransaction(), &flow.TransactionResult{}, nil)
+
+		result, err := addKey([]string{"emulator-account"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail missing key", func(t *testing.T) {
+		addKeyFlags.Key = ""
+
+		_, err := addKey([]string{"emulator-account"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "public key is required, use --key flag")
+	})
+
+	t.Run("Fail invalid weight", func(t *testing.T) {
+		addKeyFlags.Key = pkey
+		addKeyFlags.Weight = 1001
+
+		_, err := addKey([]string{"emulator-account"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "key weight must be between 0 and 1000")
+	})
+}
+
+func Test_RevokeKey(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			require.Len(t, script.Args, 1)
+			assert.Equal(t, cadence.NewInt(1), script.Args[0])
+		}).Return(tests.NewTransaction(), &flow.TransactionResult{}, nil)
+
+		result, err := revokeKey([]string{"emulator-account", "1"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail configured key", func(t *testing.T) {
+		_, err := revokeKey([]string{"emulator-account", "0"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "key 0 is the key used by account emulator-account in the configuration, use 'flow accounts rotate-key emulator-account' to replace it")
+	})
+
+	t.Run("Fail failed transaction", func(t *testing.T) {
+		srv.SendTransaction.Run(func(mock.Arguments) {}).Return(tests.NewTransaction(), &flow.TransactionResult{Error: fmt.Errorf("no key")}, nil)
+
+		_, err := revokeKey([]string{"emulator-account", "5"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "transaction failed: no key")
+	})
+}
+
+func Test_RotateKey(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		account, err := state.Accounts().ByName("emulator-account")
+		require.NoError(t, err)
+		oldKey, err := account.Key.PrivateKey()
+		require.NoError(t, err)
+
+		newKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+		require.NoError(t, err)
+		srv.Mock.On("GenerateKey", mock.Anything, crypto.ECDSA_P256, "").Return(newKey, nil)
+
+		onChain := tests.NewAccountWithAddress(account.Address.String())
+		onChain.Keys = []*flow.AccountKey{
+			{Index: 0, PublicKey: (*oldKey).PublicKey()},
+			{Index: 1, PublicKey: newKey.PublicKey()},
+		}
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(onChain, nil)
+
+		sent := make([]flowkit.Script, 0)
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			script := args.Get(2).(flowkit.Script)
+			if len(sent) > 0 { // transactions after adding the key are signed with the new key
+				assert.Equal(t, uint32(1), roles.Proposer.Key.Index())
+			}
+			sent = append(sent, script)
+		}).Return(tests.NewTransaction(), &flow.TransactionResult{}, nil)
+
+		result, err := rotateKey([]string{"emulator-account"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		require.Len(t, sent, 3)
+		assert.Equal(t, cadence.String(strings.TrimPrefix(newKey.PublicKey().String(), "0x")), sent[0].Args[0])
+		assert.Equal(t, noopTransaction, string(sent[1].Code))
+		assert.Equal(t, []cadence.Value{cadence.NewInt(0)}, sent[2].Args)
+
+		updated, err := state.Accounts().ByName("emulator-account")
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), updated.Key.Index())
+		updatedKey, err := updated.Key.PrivateKey()
+		require.NoError(t, err)
+		assert.True(t, newKey.Equals(*updatedKey))
+
+		saved, err := rw.ReadFile(updated.Key.ToConfig().Location)
+		require.NoError(t, err)
+		assert.Equal(t, newKey.String(), string(saved))
+		conf, err := rw.ReadFile("flow.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(conf), `"index": 1`)
+		assert.Equal(t, "Address: 0xf8d6e0586b0a20c7, Key Index: 1, Revoked Key Index: 0", result.Oneliner())
+	})
+
+	t.Run("Fail new key not found", func(t *testing.T) {
+		srv.SendTransaction.Run(func(mock.Arguments) {})
+		srv.GetAccount.Return(tests.NewAccountWithAddress("0xf8d6e0586b0a20c7"), nil)
+
+		_, err := rotateKey([]string{"emulator-account"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "new key was not found on account f8d6e0586b0a20c7")
+	})
+}
+
+// newTestStruct creates a struct value with the fields in the order of the field names.
+func newTestStruct(name string, fields map[string]cadence.Value, order ...string) cadence.Struct {
+	typeFields := make([]cadence.Field, 0, len(order))
+	values := make([]cadence.Value, 0, len(order))
+	for _, field := range order {
+		typeFields = append(typeFields, cadence.Field{Identifier: field, Type: cadence.AnyStructType})
+		values = append(values, fields[field])
+	}
+	return cadence.NewStruct(values).WithType(cadence.NewStructType(nil, name, typeFields, nil))
+}
+
+func Test_Storage(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	vaultType := &cadence.ResourceType{QualifiedIdentifier: "FlowToken.Vault", Location: common.NewAddressLocation(nil, common.MustBytesToAddress([]byte{0x01}), "FlowToken")}
+	vaultReference := cadence.NewReferenceType(cadence.UnauthorizedAccess, vaultType)
+	storagePath := cadence.Path{Domain: common.PathDomainStorage, Identifier: "flowTokenVault"}
+
+	t.Run("Success", func(t *testing.T) {
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Equal(t, storageScript, string(script.Code))
+			assert.Equal(t, []cadence.Value{cadence.NewAddress(flow.HexToAddress("0x01"))}, script.Args)
+		}).Return(newTestStruct("StorageInfo", map[string]cadence.Value{
+			"used":     cadence.UInt64(1024),
+			"capacity": cadence.UInt64(100000),
+			"stored": cadence.NewArray([]cadence.Value{
+				newTestStruct("StoredPath", map[string]cadence.Value{
+					"path": cadence.Path{Domain: common.PathDomainStorage, Identifier: "nfts"},
+					"type": cadence.NewTypeValue(cadence.StringType),
+				}, "path", "type"),
+				newTestStruct("StoredPath", map[string]cadence.Value{
+					"path": storagePath,
+					"type": cadence.NewTypeValue(vaultType),
+				}, "path", "type"),
+			}),
+			"publicCapabilities": cadence.NewArray([]cadence.Value{
+				newTestStruct("PublicCapability", map[string]cadence.Value{
+					"path": cadence.Path{Domain: common.PathDomainPublic, Identifier: "flowTokenBalance"},
+					"type": cadence.NewTypeValue(cadence.NewCapabilityType(vaultReference)),
+				}, "path", "type"),
+			}),
+			"privateCapabilities": cadence.NewArray([]cadence.Value{
+				newTestStruct("PrivateCapability", map[string]cadence.Value{
+					"id":         cadence.UInt64(2),
+					"borrowType": cadence.NewTypeValue(vaultReference),
+					"target":     cadence.NewOptional(nil),
+					"tag":        cadence.String("owner"),
+				}, "id", "borrowType", "target", "tag"),
+				newTestStruct("PrivateCapability", map[string]cadence.Value{
+					"id":         cadence.UInt64(1),
+					"borrowType": cadence.NewTypeValue(vaultReference),
+					"target":     cadence.NewOptional(storagePath),
+					"tag":        cadence.String(""),
+				}, "id", "borrowType", "target", "tag"),
+			}),
+		}, "used", "capacity", "stored", "publicCapabilities", "privateCapabilities"), nil)
+
+		result, err := storage([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{
+			"address":  "0x0000000000000001",
+			"used":     uint64(1024),
+			"capacity": uint64(100000),
+			"stored": []any{
+				map[string]any{"path": "/storage/flowTokenVault", "type": "A.0000000000000001.FlowToken.Vault"},
+				map[string]any{"path": "/storage/nfts", "type": "String"},
+			},
+			"publicCapabilities": []any{
+				map[string]any{
+					"path":       "/public/flowTokenBalance",
+					"type":       "Capability<&A.0000000000000001.FlowToken.Vault>",
+					"borrowType": "&A.0000000000000001.FlowToken.Vault",
+				},
+			},
+			"privateCapabilities": []any{
+				map[string]any{"id": uint64(1), "borrowType": "&A.0000000000000001.FlowToken.Vault", "tag": "", "target": "/storage/flowTokenVault"},
+				map[string]any{"id": uint64(2), "borrowType": "&A.0000000000000001.FlowToken.Vault", "tag": "owner"},
+			},
+		}, result.JSON())
+		assert.Equal(t, "Address: 0x0000000000000001, Used: 1024, Capacity: 100000, Stored Paths: /storage/flowTokenVault, /storage/nfts", result.Oneliner())
+		assert.Contains(t, result.String(), "#2 account")
+	})
+
+	t.Run("Fail script error", func(t *testing.T) {
+		srv.ExecuteScript.Run(func(mock.Arguments) {}).Return(nil, fmt.Errorf("account not found"))
+
+		_, err := storage([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "error inspecting account storage: account not found")
+	})
+}
+
+func Test_Assets(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	vaultType := &cadence.ResourceType{QualifiedIdentifier: "FlowToken.Vault", Location: common.NewAddressLocation(nil, common.MustBytesToAddress([]byte{0x01}), "FlowToken")}
+	collectionType := &cadence.ResourceType{QualifiedIdentifier: "TopShot.Collection", Location: common.NewAddressLocation(nil, common.MustBytesToAddress([]byte{0x02}), "TopShot")}
+
+	t.Run("Success", func(t *testing.T) {
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Contains(t, string(script.Code), "import FungibleToken from 0xf233dcee88fe0abe")
+			assert.Contains(t, string(script.Code), "import NonFungibleToken from 0x1d7e57aa55817448")
+			assert.Contains(t, string(script.Code), "import MetadataViews from 0x1d7e57aa55817448")
+			assert.Equal(t, cadence.NewInt(10), script.Args[1])
+		}).Return(newTestStruct("Assets", map[string]cadence.Value{
+			"tokens": cadence.NewArray([]cadence.Value{
+				newTestStruct("Token", map[string]cadence.Value{
+					"path":    cadence.Path{Domain: common.PathDomainStorage, Identifier: "flowTokenVault"},
+					"type":    cadence.NewTypeValue(vaultType),
+					"balance": cadence.UFix64(1050000000),
+					"name":    cadence.NewOptional(cadence.String("FLOW Network Token")),
+					"symbol":  cadence.NewOptional(cadence.String("FLOW")),
+				}, "path", "type", "balance", "name", "symbol"),
+			}),
+			"collections": cadence.NewArray([]cadence.Value{
+				newTestStruct("Collection", map[string]cadence.Value{
+					"path":        cadence.Path{Domain: common.PathDomainStorage, Identifier: "MomentCollection"},
+					"type":        cadence.NewTypeValue(collectionType),
+					"count":       cadence.NewInt(3),
+					"name":        cadence.NewOptional(cadence.String("NBA Top Shot")),
+					"description": cadence.NewOptional(nil),
+					"nfts": cadence.NewArray([]cadence.Value{
+						newTestStruct("NFT", map[string]cadence.Value{
+							"id":          cadence.UInt64(42),
+							"name":        cadence.NewOptional(cadence.String("Dunk")),
+							"description": cadence.NewOptional(nil),
+							"thumbnail":   cadence.NewOptional(cadence.String("https://example.com/42.png")),
+						}, "id", "name", "description", "thumbnail"),
+					}),
+				}, "path", "type", "count", "name", "description", "nfts"),
+			}),
+		}, "tokens", "collections"), nil)
+
+		result, err := assets([]string{"0x1654653399040a61"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Tokens: FLOW: 10.50000000, Collections: NBA Top Shot: 3", result.Oneliner())
+		assert.Contains(t, result.String(), "2 more")
+		assert.Equal(t, map[string]any{
+			"id":          uint64(42),
+			"name":        "Dunk",
+			"description": "",
+			"thumbnail":   "https://example.com/42.png",
+		}, result.JSON().(map[string]any)["collections"].([]any)[0].(map[string]any)["nfts"].([]any)[0])
+	})
+
+	t.Run("Fail invalid address", func(t *testing.T) {
+		_, err := assets([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "failed to determine network from address, check the address and network")
+	})
+}
+
+func Test_Fund(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	require.NoError(t, state.SaveDefault())
+	globalFlags := command.GlobalFlags{ConfigPaths: config.DefaultPaths()}
+
+	t.Run("Success emulator account by name", func(t *testing.T) {
+		fundFlags.Amount = "100.0"
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			script := args.Get(2).(flowkit.Script)
+			assert.Equal(t, "emulator-account", roles.Payer.Name)
+			assert.Contains(t, string(script.Code), "import 0x0ae53cb6e3f42a79")
+			assert.Equal(t, []cadence.Value{
+				cadence.NewAddress(flow.HexToAddress("f8d6e0586b0a20c7")),
+				cadence.UFix64(10000000000),
+			}, script.Args)
+		}).Return(tests.NewTransaction(), &flow.TransactionResult{}, nil)
+
+		result, err := fund([]string{"emulator-account"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success emulator address", func(t *testing.T) {
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Equal(t, cadence.NewAddress(flow.HexToAddress("01cf0e2f2f715450")), script.Args[0])
+		})
+
+		result, err := fund([]string{"01cf0e2f2f715450"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail invalid amount", func(t *testing.T) {
+		fundFlags.Amount = "invalid"
+
+		_, err := fund([]string{"emulator-account"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "invalid amount invalid")
+	})
+
+	t.Run("Fail emulator without configuration", func(t *testing.T) {
+		_, _, emptyRw := util.TestMocks(t)
+
+		_, err := fund([]string{"01cf0e2f2f715450"}, globalFlags, util.NoLogger, emptyRw, srv.Mock)
+		assert.EqualError(t, err, "funding emulator accounts requires a configuration with the emulator service account, run 'flow init' to create one")
+	})
+
+	t.Run("Fail address not on the network", func(t *testing.T) {
+		_, err := fund([]string{"8e94eaa81771313a"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "address 8e94eaa81771313a is not valid on the emulator network, use the --network flag to fund accounts on other networks")
+
+		srv.Network.Return(config.TestnetNetwork)
+		defer srv.Network.Return(config.EmulatorNetwork)
+
+		_, err = fund([]string{"0x01"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "unsupported address 0000000000000001, faucet can only work for valid Testnet addresses")
+	})
+
+	t.Run("Fail unsupported network", func(t *testing.T) {
+		srv.Network.Return(config.MainnetNetwork)
+		defer srv.Network.Return(config.EmulatorNetwork)
+
+		_, err := fund([]string{"8e94eaa81771313a"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "funding accounts is only supported on the emulator and testnet networks")
+	})
+
+	fundFlags.Amount = "1000.0"
+}
+
+func Test_Diff(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	code := "access(all) contract Foo {\n\tinit() {}\n\n\taccess(all) fun a() {}\n\taccess(all) fun b() {}\n\taccess(all) fun c() {}\n\taccess(all) fun d() {}\n\taccess(all) fun e() {}\n}\n"
+	changed := strings.Replace(code, "fun e()", "fun f()", 1)
+
+	testnetAccount := tests.NewAccountWithAddress("0x9a0766d93b6608b7")
+	testnetAccount.Contracts = map[string][]byte{"Foo": []byte(code), "Bar": []byte("bar"), "Baz": []byte("baz")}
+	pubKeys := tests.PubKeys()
+	testnetAccount.Keys = []*flow.AccountKey{
+		{Index: 0, PublicKey: pubKeys[0], Weight: 1000, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Revoked: true},
+		{Index: 1, PublicKey: pubKeys[1], Weight: 500, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256},
+		{Index: 2, PublicKey: pubKeys[2], Weight: 500, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256},
+	}
+
+	mainnetAccount := tests.NewAccountWithAddress("0xf233dcee88fe0abe")
+	mainnetAccount.Contracts = map[string][]byte{"Foo": []byte(changed), "Bar": []byte("bar"), "Qux": []byte("qux")}
+	mainnetAccount.Keys = []*flow.AccountKey{
+		{Index: 0, PublicKey: pubKeys[0], Weight: 1000, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256},
+		{Index: 1, PublicKey: pubKeys[1], Weight: 500, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256},
+		{Index: 3, PublicKey: pubKeys[3], Weight: 1000, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256},
+	}
+
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(testnetAccount, nil)
+	defaultGetNetworkAccount := getNetworkAccount
+	defer func() { getNetworkAccount = defaultGetNetworkAccount }()
+	getNetworkAccount = func(network config.Network, address flow.Address) (*flow.Account, error) {
+		assert.Equal(t, config.MainnetNetwork, network)
+		assert.Equal(t, "f233dcee88fe0abe", address.String())
+		return mainnetAccount, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := diffAccounts(
+			[]string{"0x9a0766d93b6608b7", "0xf233dcee88fe0abe@mainnet"},
+			command.GlobalFlags{},
+			util.NoLogger,
+			rw,
+			srv.Mock,
+		)
+		require.NoError(t, err)
+
+		diffResult := result.(*accountsDiffResult)
+		assert.Equal(t, 1, diffResult.ExitCode())
+		assert.Equal(t, "Baz: only in first, Foo: different, Qux: only in second", result.Oneliner())
+
+		assert.Equal(t, `--- 0x9a0766d93b6608b7@emulator/Foo
++++ 0xf233dcee88fe0abe@mainnet/Foo
+@@ -5,5 +5,5 @@
+ 	access(all) fun b() {}
+ 	access(all) fun c() {}
+ 	access(all) fun d() {}
+-	access(all) fun e() {}
++	access(all) fun f() {}
+ }
+`, diffResult.contracts[2].diff)
+		assert.Equal(t, []keyDiff{
+			{index: 0, status: keyDifferent, a: testnetAccount.Keys[0], b: mainnetAccount.Keys[0]},
+			{index: 1, status: keyIdentical, a: testnetAccount.Keys[1], b: mainnetAccount.Keys[1]},
+			{index: 2, status: keyRemoved, a: testnetAccount.Keys[2]},
+			{index: 3, status: keyAdded, b: mainnetAccount.Keys[2]},
+		}, diffResult.keys)
+		assert.Contains(t, result.String(), "weight 1000, ECDSA_P256, SHA3_256 (revoked) -> weight 1000, ECDSA_P256, SHA3_256")
+	})
+
+	t.Run("Fail unknown network", func(t *testing.T) {
+		_, err := diffAccounts(
+			[]string{"0x9a0766d93b6608b7", "0xf233dcee88fe0abe@unknown"},
+			command.GlobalFlags{},
+			util.NoLogger,
+			rw,
+			srv.Mock,
+		)
+		assert.EqualError(t, err, "network named unknown does not exist in configuration")
+	})
+}
+
+func Test_ExportContracts(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	require.NoError(t, state.SaveDefault())
+	globalFlags := command.GlobalFlags{ConfigPaths: config.DefaultPaths()}
+
+	account := tests.NewAccountWithAddress("0x01")
+	account.Contracts = map[string][]byte{
+		"Foo": []byte("import Bar from 0x0000000000000001\n\naccess(all) contract Foo {}\n"),
+		"Bar": []byte("access(all) contract Bar {}\n"),
+	}
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(account, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		exportContractsFlags.Dir = "exported"
+		exportContractsFlags.Register = true
+
+		result, err := exportContracts([]string{"0x01"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("exported", "Bar.cdc")+", "+filepath.Join("exported", "Foo.cdc"), result.Oneliner())
+
+		code, err := rw.ReadFile(filepath.Join("exported", "Foo.cdc"))
+		require.NoError(t, err)
+		assert.Equal(t, "import \"Bar\"\n\naccess(all) contract Foo {}\n", string(code))
+
+		loaded, err := flowkit.Load(config.DefaultPaths(), rw)
+		require.NoError(t, err)
+		contract, err := loaded.Contracts().ByName("Foo")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("exported", "Foo.cdc"), contract.Location)
+		assert.Equal(t, "0000000000000001", contract.Aliases.ByNetwork("emulator").Address.String())
+	})
+
+	t.Run("Fail existing contract location", func(t *testing.T) {
+		exportContractsFlags.Dir = "other"
+		exportContractsFlags.Register = true
+
+		_, err := exportContracts([]string{"0x01"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, fmt.Sprintf(
+			"contract Bar is already in the configuration with location %s, use --overwrite to replace it",
+			filepath.Join("exported", "Bar.cdc"),
+		))
+
+		exportContractsFlags.Overwrite = true
+		_, err = exportContracts([]string{"0x01"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		exportContractsFlags.Overwrite = false
+
+		loaded, err := flowkit.Load(config.DefaultPaths(), rw)
+		require.NoError(t, err)
+		contract, err := loaded.Contracts().ByName("Bar")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("other", "Bar.cdc"), contract.Location)
+	})
+
+	t.Run("Success relative to configuration", func(t *testing.T) {
+		require.NoError(t, state.Save(filepath.Join("project", "flow.json")))
+		exportContractsFlags.Dir = filepath.Join("project", "imports")
+		exportContractsFlags.Register = true
+		projectFlags := command.GlobalFlags{ConfigPaths: []string{filepath.Join("project", "flow.json")}}
+
+		_, err := exportContracts([]string{"0x01"}, projectFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		loaded, err := flowkit.Load(projectFlags.ConfigPaths, rw)
+		require.NoError(t, err)
+		contract, err := loaded.Contracts().ByName("Foo")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("imports", "Foo.cdc"), contract.Location)
+	})
+
+	t.Run("Fail existing file", func(t *testing.T) {
+		exportContractsFlags.Dir = "exported"
+		exportContractsFlags.Register = false
+
+		_, err := exportContracts([]string{"0x01"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, fmt.Sprintf("file %s already exists, use --overwrite to replace it", filepath.Join("exported", "Bar.cdc")))
+	})
+
+	t.Run("Fail no contracts", func(t *testing.T) {
+		srv.GetAccount.Return(tests.NewAccountWithAddress("0x02"), nil)
+
+		_, err := exportContracts([]string{"0x02"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "no contracts deployed to account 0x0000000000000002")
+	})
+
+	exportContractsFlags = flagsExportContracts{Dir: "imports"}
+}
+
 func Test_Result(t *testing.T) {
 	pkey, _ := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, "a60b9c10a39070806d37d8f0e6be081e7af2d18cd92ee1bd850d10c994d61d538d2693eebe8faa94fea59ee579ea65a70ed897b05126e508e74f55b8669eec6b")
 	account := &flow.Account{
@@ -0,0 +1,421 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	flowGo "github.com/onflow/flow-go/model/flow"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsAssets struct {
+	Limit int `default:"10" flag:"limit" info:"Maximum number of NFTs per collection to show display data for"`
+}
+
+var assetsFlags = flagsAssets{}
+
+var assetsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "assets <address>",
+		Short: "List fungible token balances and NFT collections of an account",
+		Long: `List fungible token balances and NFT collections of an account.
+
+All the FungibleToken vaults and NonFungibleToken collections in the account storage are shown together with
+their MetadataViews display data.`,
+		Example: `flow accounts assets 0x1654653399040a61 --network mainnet`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &assetsFlags,
+	Run:   assets,
+}
+
+// assetsScript finds the vaults and collections in the account storage and resolves their display views,
+// the standard contract imports are replaced with the addresses for the network.
+const assetsScript = `
+import "FungibleToken"
+import "FungibleTokenMetadataViews"
+import "NonFungibleToken"
+import "MetadataViews"
+
+access(all) struct Token {
+	access(all) let path: StoragePath
+	access(all) let type: Type
+	access(all) let balance: UFix64
+	access(all) let name: String?
+	access(all) let symbol: String?
+
+	init(path: StoragePath, type: Type, balance: UFix64, name: String?, symbol: String?) {
+		self.path = path
+		self.type = type
+		self.balance = balance
+		self.name = name
+		self.symbol = symbol
+	}
+}
+
+access(all) struct NFT {
+	access(all) let id: UInt64
+	access(all) let name: String?
+	access(all) let description: String?
+	access(all) let thumbnail: String?
+
+	init(id: UInt64, name: String?, description: String?, thumbnail: String?) {
+		self.id = id
+		self.name = name
+		self.description = description
+		self.thumbnail = thumbnail
+	}
+}
+
+access(all) struct Collection {
+	access(all) let path: StoragePath
+	access(all) let type: Type
+	access(all) let count: Int
+	access(all) let name: String?
+	access(all) let description: String?
+	access(all) let nfts: [NFT]
+
+	init(path: StoragePath, type: Type, count: Int, name: String?, description: String?, nfts: [NFT]) {
+		self.path = path
+		self.type = type
+		self.count = count
+		self.name = name
+		self.description = description
+		self.nfts = nfts
+	}
+}
+
+access(all) struct Assets {
+	access(all) let tokens: [Token]
+	access(all) let collections: [Collection]
+
+	init(tokens: [Token], collections: [Collection]) {
+		self.tokens = tokens
+		self.collections = collections
+	}
+}
+
+access(all) fun main(address: Address, limit: Int): Assets {
+	let account = getAuthAccount<auth(BorrowValue) &Account>(address)
+	let tokens: [Token] = []
+	let collections: [Collection] = []
+
+	account.storage.forEachStored(fun (path: StoragePath, type: Type): Bool {
+		if type.isRecovered {
+			return true
+		}
+
+		if type.isSubtype(of: Type<@{FungibleToken.Vault}>()) {
+			let vault = account.storage.borrow<&{FungibleToken.Vault}>(from: path)!
+			var name: String? = nil
+			var symbol: String? = nil
+			if let display = vault.resolveView(Type<FungibleTokenMetadataViews.FTDisplay>()) as? FungibleTokenMetadataViews.FTDisplay {
+				name = display.name
+				symbol = display.symbol
+			}
+			tokens.append(Token(path: path, type: type, balance: vault.balance, name: name, symbol: symbol))
+		}
+
+		if type.isSubtype(of: Type<@{NonFungibleToken.Collection}>()) {
+			let collection = account.storage.borrow<&{NonFungibleToken.Collection}>(from: path)!
+			let ids = collection.getIDs()
+			var name: String? = nil
+			var description: String? = nil
+			let nfts: [NFT] = []
+
+			for id in ids {
+				if nfts.length >= limit {
+					break
+				}
+				let nft = collection.borrowNFT(id)
+				if nft == nil {
+					continue
+				}
+				if name == nil {
+					if let display = nft!.resolveView(Type<MetadataViews.NFTCollectionDisplay>()) as? MetadataViews.NFTCollectionDisplay {
+						name = display.name
+						description = display.description
+					}
+				}
+				if let display = nft!.resolveView(Type<MetadataViews.Display>()) as? MetadataViews.Display {
+					nfts.append(NFT(id: id, name: display.name, description: display.description, thumbnail: display.thumbnail.uri()))
+				} else {
+					nfts.append(NFT(id: id, name: nil, description: nil, thumbnail: nil))
+				}
+			}
+
+			collections.append(Collection(path: path, type: type, count: ids.length, name: name, description: description, nfts: nfts))
+		}
+
+		return true
+	})
+
+	return Assets(tokens: tokens, collections: collections)
+}
+`
+
+func assets(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address := flowsdk.HexToAddress(args[0])
+
+	chain, err := util.GetAddressNetwork(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine network from address, check the address and network")
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading assets of account %s...", address))
+	defer logger.StopProgress()
+
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: assetsScriptForChain(chain),
+			Args: []cadence.Value{cadence.NewAddress(address), cadence.NewInt(assetsFlags.Limit)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error loading account assets: %w", err)
+	}
+
+	return newAssetsResult(address, value)
+}
+
+// assetsScriptForChain replaces the standard contract imports with their addresses on the chain.
+func assetsScriptForChain(chain flowsdk.ChainID) []byte {
+	sc := systemcontracts.SystemContractsForChain(flowGo.ChainID(chain))
+
+	replacer := strings.NewReplacer(
+		`"FungibleTokenMetadataViews"`, fmt.Sprintf("FungibleTokenMetadataViews from 0x%s", sc.FungibleTokenMetadataViews.Address.Hex()),
+		`"FungibleToken"`, fmt.Sprintf("FungibleToken from 0x%s", sc.FungibleToken.Address.Hex()),
+		`"NonFungibleToken"`, fmt.Sprintf("NonFungibleToken from 0x%s", sc.NonFungibleToken.Address.Hex()),
+		`"MetadataViews"`, fmt.Sprintf("MetadataViews from 0x%s", sc.MetadataViews.Address.Hex()),
+	)
+
+	return []byte(replacer.Replace(assetsScript))
+}
+
+type tokenAsset struct {
+	path    string
+	typ     string
+	balance string
+	name    string
+	symbol  string
+}
+
+type nftAsset struct {
+	id          uint64
+	name        string
+	description string
+	thumbnail   string
+}
+
+type collectionAsset struct {
+	path        string
+	typ         string
+	count       int
+	name        string
+	description string
+	nfts        []nftAsset
+}
+
+type assetsResult struct {
+	address     flowsdk.Address
+	tokens      []tokenAsset
+	collections []collectionAsset
+}
+
+// newAssetsResult converts the value returned by the assets script.
+func newAssetsResult(address flowsdk.Address, value cadence.Value) (*assetsResult, error) {
+	info, ok := value.(cadence.Struct)
+	if !ok {
+		return nil, fmt.Errorf("unexpected assets script result: %s", value)
+	}
+	fields := cadence.FieldsMappedByName(info)
+
+	result := &assetsResult{
+		address:     address,
+		tokens:      make([]tokenAsset, 0),
+		collections: make([]collectionAsset, 0),
+	}
+
+	for _, item := range structs(fields["tokens"]) {
+		result.tokens = append(result.tokens, tokenAsset{
+			path:    item["path"].String(),
+			typ:     typeID(item["type"]),
+			balance: item["balance"].String(),
+			name:    optionalString(item["name"]),
+			symbol:  optionalString(item["symbol"]),
+		})
+	}
+
+	for _, item := range structs(fields["collections"]) {
+		collection := collectionAsset{
+			path:        item["path"].String(),
+			typ:         typeID(item["type"]),
+			name:        optionalString(item["name"]),
+			description: optionalString(item["description"]),
+			nfts:        make([]nftAsset, 0),
+		}
+		if count, ok := item["count"].(cadence.Int); ok {
+			collection.count = count.Int()
+		}
+		for _, nft := range structs(item["nfts"]) {
+			asset := nftAsset{
+				name:        optionalString(nft["name"]),
+				description: optionalString(nft["description"]),
+				thumbnail:   optionalString(nft["thumbnail"]),
+			}
+			if id, ok := nft["id"].(cadence.UInt64); ok {
+				asset.id = uint64(id)
+			}
+			collection.nfts = append(collection.nfts, asset)
+		}
+		result.collections = append(result.collections, collection)
+	}
+
+	// iteration order of the account storage is not defined, so it's sorted for stable output
+	sort.Slice(result.tokens, func(i, j int) bool {
+		return result.tokens[i].path < result.tokens[j].path
+	})
+	sort.Slice(result.collections, func(i, j int) bool {
+		return result.collections[i].path < result.collections[j].path
+	})
+
+	return result, nil
+}
+
+// optionalString returns the string value of the optional or an empty string if it's nil.
+func optionalString(value cadence.Value) string {
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+	if str, ok := value.(cadence.String); ok {
+		return string(str)
+	}
+	return ""
+}
+
+func (r *assetsResult) JSON() any {
+	tokens := make([]any, 0, len(r.tokens))
+	for _, token := range r.tokens {
+		tokens = append(tokens, map[string]any{
+			"path":    token.path,
+			"type":    token.typ,
+			"balance": token.balance,
+			"name":    token.name,
+			"symbol":  token.symbol,
+		})
+	}
+
+	collections := make([]any, 0, len(r.collections))
+	for _, collection := range r.collections {
+		nfts := make([]any, 0, len(collection.nfts))
+		for _, nft := range collection.nfts {
+			nfts = append(nfts, map[string]any{
+				"id":          nft.id,
+				"name":        nft.name,
+				"description": nft.description,
+				"thumbnail":   nft.thumbnail,
+			})
+		}
+		collections = append(collections, map[string]any{
+			"path":        collection.path,
+			"type":        collection.typ,
+			"count":       collection.count,
+			"name":        collection.name,
+			"description": collection.description,
+			"nfts":        nfts,
+		})
+	}
+
+	return map[string]any{
+		"address":     r.address.HexWithPrefix(),
+		"tokens":      tokens,
+		"collections": collections,
+	}
+}
+
+func (r *assetsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address\t %s\n", r.address.HexWithPrefix())
+
+	_, _ = fmt.Fprintf(writer, "\nFungible Tokens\t %d\n", len(r.tokens))
+	for _, token := range r.tokens {
+		_, _ = fmt.Fprintf(writer, "  %s\t %s %s\n", displayName(token.name, token.typ), token.balance, token.symbol)
+		_, _ = fmt.Fprintf(writer, "  \t %s\n", token.path)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nNFT Collections\t %d\n", len(r.collections))
+	for _, collection := range r.collections {
+		_, _ = fmt.Fprintf(writer, "  %s\t %d NFTs\n", displayName(collection.name, collection.typ), collection.count)
+		_, _ = fmt.Fprintf(writer, "  \t %s\n", collection.path)
+		for _, nft := range collection.nfts {
+			_, _ = fmt.Fprintf(writer, "    #%d\t %s\n", nft.id, nft.name)
+		}
+		if len(collection.nfts) < collection.count {
+			_, _ = fmt.Fprintf(writer, "    ...\t %d more\n", collection.count-len(collection.nfts))
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *assetsResult) Oneliner() string {
+	tokens := make([]string, 0, len(r.tokens))
+	for _, token := range r.tokens {
+		tokens = append(tokens, fmt.Sprintf("%s: %s", displayName(token.symbol, token.typ), token.balance))
+	}
+	collections := make([]string, 0, len(r.collections))
+	for _, collection := range r.collections {
+		collections = append(collections, fmt.Sprintf("%s: %d", displayName(collection.name, collection.typ), collection.count))
+	}
+
+	return fmt.Sprintf("Tokens: %s, Collections: %s", strings.Join(tokens, ", "), strings.Join(collections, ", "))
+}
+
+// displayName returns the name from the display view or the type if the view is not available.
+func displayName(name string, typ string) string {
+	if name != "" {
+		return name
+	}
+	return typ
+}
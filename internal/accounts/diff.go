@@ -0,0 +1,400 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/config"
+	"github.com/onflow/flowkit/v2/gateway"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDiff struct{}
+
+var diffFlags = flagsDiff{}
+
+var diffCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "diff <address>[@<network>] <address>[@<network>]",
+		Short: "Compare the contracts and keys of two accounts",
+		Long: `Compare the contracts and keys of two accounts, which can be on different networks.
+
+Accounts are provided as an address or an account name from the configuration, optionally followed by @ and the
+network name. The network from the --network flag is used if none is provided.`,
+		Example: `flow accounts diff 0x9a0766d93b6608b7@testnet 0xf233dcee88fe0abe@mainnet`,
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &diffFlags,
+	Run:   diffAccounts,
+}
+
+// getNetworkAccount fetches the account from a network other than the one of the command.
+var getNetworkAccount = func(network config.Network, address flowsdk.Address) (*flowsdk.Account, error) {
+	var gw gateway.Gateway
+	var err error
+	if network.Key != "" {
+		gw, err = gateway.NewSecureGrpcGateway(network)
+	} else {
+		gw, err = gateway.NewGrpcGateway(network)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return gw.GetAccount(context.Background(), address)
+}
+
+func diffAccounts(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// configuration is optional and used to resolve account and network names
+	state, err := signer.Load(globalFlags.ConfigPaths, readerWriter)
+	if err != nil && !errors.Is(err, config.ErrDoesNotExist) {
+		return nil, err
+	}
+
+	logger.StartProgress("Loading accounts...")
+	defer logger.StopProgress()
+
+	sides := make([]accountSide, 0, len(args))
+	for _, arg := range args {
+		side, err := loadAccountSide(arg, state, flow)
+		if err != nil {
+			return nil, err
+		}
+		sides = append(sides, side)
+	}
+
+	return newAccountsDiffResult(sides[0], sides[1]), nil
+}
+
+// accountSide is one of the compared accounts together with the network it was loaded from.
+type accountSide struct {
+	network string
+	account *flowsdk.Account
+}
+
+func (s accountSide) name() string {
+	return fmt.Sprintf("%s@%s", s.account.Address.HexWithPrefix(), s.network)
+}
+
+// loadAccountSide loads the account from the argument in the format <address|name>[@<network>].
+func loadAccountSide(arg string, state *flowkit.State, flow flowkit.Services) (accountSide, error) {
+	value, networkName, _ := strings.Cut(arg, "@")
+	if networkName == "" {
+		networkName = flow.Network().Name
+	}
+
+	address := flowsdk.HexToAddress(value)
+	if state != nil {
+		if account, err := state.Accounts().ByName(value); err == nil {
+			address = account.Address
+		}
+	}
+	if address == flowsdk.EmptyAddress {
+		return accountSide{}, fmt.Errorf("invalid account %s, use an address or an account name from the configuration", value)
+	}
+
+	var account *flowsdk.Account
+	var err error
+	if networkName == flow.Network().Name {
+		account, err = flow.GetAccount(context.Background(), address)
+	} else {
+		networks := config.DefaultNetworks
+		if state != nil {
+			networks = *state.Networks()
+		}
+		network, networkErr := networks.ByName(networkName)
+		if networkErr != nil {
+			return accountSide{}, networkErr
+		}
+		account, err = getNetworkAccount(*network, address)
+	}
+	if err != nil {
+		return accountSide{}, fmt.Errorf("failed to get account %s on %s: %w", address.HexWithPrefix(), networkName, err)
+	}
+
+	return accountSide{network: networkName, account: account}, nil
+}
+
+const (
+	contractIdentical  = "identical"
+	contractDifferent  = "different"
+	contractOnlyFirst  = "only in first"
+	contractOnlySecond = "only in second"
+)
+
+type contractDiff struct {
+	name   string
+	status string
+	diff   string
+}
+
+const (
+	keyIdentical = "identical"
+	keyDifferent = "different"
+	keyAdded     = "added"
+	keyRemoved   = "removed"
+)
+
+// keyDiff compares the keys with the same index, a key only on the second account is added and
+// a key only on the first account is removed.
+type keyDiff struct {
+	index  uint32
+	status string
+	a      *flowsdk.AccountKey
+	b      *flowsdk.AccountKey
+}
+
+type accountsDiffResult struct {
+	a         accountSide
+	b         accountSide
+	contracts []contractDiff
+	keys      []keyDiff
+}
+
+func newAccountsDiffResult(a accountSide, b accountSide) *accountsDiffResult {
+	names := make(map[string]bool)
+	for name := range a.account.Contracts {
+		names[name] = true
+	}
+	for name := range b.account.Contracts {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	contracts := make([]contractDiff, 0, len(sorted))
+	for _, name := range sorted {
+		codeA, inA := a.account.Contracts[name]
+		codeB, inB := b.account.Contracts[name]
+
+		diff := contractDiff{name: name}
+		switch {
+		case !inA:
+			diff.status = contractOnlySecond
+		case !inB:
+			diff.status = contractOnlyFirst
+		case bytes.Equal(codeA, codeB):
+			diff.status = contractIdentical
+		default:
+			diff.status = contractDifferent
+			diff.diff = util.UnifiedDiff(
+				fmt.Sprintf("%s/%s", a.name(), name),
+				fmt.Sprintf("%s/%s", b.name(), name),
+				string(codeA),
+				string(codeB),
+			)
+		}
+		contracts = append(contracts, diff)
+	}
+
+	return &accountsDiffResult{a: a, b: b, contracts: contracts, keys: diffKeys(a.account.Keys, b.account.Keys)}
+}
+
+func diffKeys(a []*flowsdk.AccountKey, b []*flowsdk.AccountKey) []keyDiff {
+	byIndex := make(map[uint32]*keyDiff)
+	indexes := make([]uint32, 0)
+	for _, key := range a {
+		byIndex[key.Index] = &keyDiff{index: key.Index, status: keyRemoved, a: key}
+		indexes = append(indexes, key.Index)
+	}
+	for _, key := range b {
+		diff, ok := byIndex[key.Index]
+		if !ok {
+			byIndex[key.Index] = &keyDiff{index: key.Index, status: keyAdded, b: key}
+			indexes = append(indexes, key.Index)
+			continue
+		}
+
+		diff.b = key
+		diff.status = keyDifferent
+		if keysEqual(diff.a, key) {
+			diff.status = keyIdentical
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	keys := make([]keyDiff, 0, len(indexes))
+	for _, index := range indexes {
+		keys = append(keys, *byIndex[index])
+	}
+	return keys
+}
+
+func keysEqual(a *flowsdk.AccountKey, b *flowsdk.AccountKey) bool {
+	return a.PublicKey.Equals(b.PublicKey) &&
+		a.Weight == b.Weight &&
+		a.SigAlgo == b.SigAlgo &&
+		a.HashAlgo == b.HashAlgo &&
+		a.Revoked == b.Revoked
+}
+
+func describeKey(key *flowsdk.AccountKey) string {
+	revoked := ""
+	if key.Revoked {
+		revoked = " (revoked)"
+	}
+	return fmt.Sprintf("weight %d, %s, %s%s", key.Weight, key.SigAlgo, key.HashAlgo, revoked)
+}
+
+func (r *accountsDiffResult) JSON() any {
+	contracts := make([]any, 0, len(r.contracts))
+	for _, contract := range r.contracts {
+		item := map[string]any{
+			"name":   contract.name,
+			"status": contract.status,
+		}
+		if contract.diff != "" {
+			item["diff"] = contract.diff
+		}
+		contracts = append(contracts, item)
+	}
+
+	keys := make([]any, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, map[string]any{
+			"index":  key.index,
+			"status": key.status,
+		})
+	}
+
+	return map[string]any{
+		"accounts":  []any{sideJSON(r.a), sideJSON(r.b)},
+		"contracts": contracts,
+		"keys":      keys,
+	}
+}
+
+func sideJSON(side accountSide) map[string]any {
+	keys := make([]any, 0, len(side.account.Keys))
+	for _, key := range side.account.Keys {
+		keys = append(keys, map[string]any{
+			"index":     key.Index,
+			"publicKey": fmt.Sprintf("%x", key.PublicKey.Encode()),
+			"weight":    key.Weight,
+			"sigAlgo":   key.SigAlgo.String(),
+			"hashAlgo":  key.HashAlgo.String(),
+			"revoked":   key.Revoked,
+		})
+	}
+
+	return map[string]any{
+		"address": side.account.Address.HexWithPrefix(),
+		"network": side.network,
+		"keys":    keys,
+	}
+}
+
+func (r *accountsDiffResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Comparing %s with %s\n\n", r.a.name(), r.b.name())
+
+	_, _ = fmt.Fprintf(writer, "Contracts\t %d\n", len(r.contracts))
+	for _, contract := range r.contracts {
+		emoji := output.OkEmoji()
+		if contract.status != contractIdentical {
+			emoji = output.ErrorEmoji()
+		}
+		_, _ = fmt.Fprintf(writer, "  %s %s\t %s\n", emoji, contract.name, contract.status)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nKeys\t %d\n", len(r.keys))
+	for _, key := range r.keys {
+		emoji := output.OkEmoji()
+		if key.status != keyIdentical {
+			emoji = output.ErrorEmoji()
+		}
+
+		var details string
+		switch key.status {
+		case keyAdded:
+			details = describeKey(key.b)
+		case keyRemoved:
+			details = describeKey(key.a)
+		case keyDifferent:
+			details = fmt.Sprintf("%s -> %s", describeKey(key.a), describeKey(key.b))
+			if !key.a.PublicKey.Equals(key.b.PublicKey) {
+				details += ", different public key"
+			}
+		default:
+			details = describeKey(key.a)
+		}
+		_, _ = fmt.Fprintf(writer, "  %s Key %d\t %s\t %s\n", emoji, key.index, key.status, details)
+	}
+	_ = writer.Flush()
+
+	// diffs are written after flushing so their lines are not aligned by the tab writer
+	for _, contract := range r.contracts {
+		if contract.diff != "" {
+			b.WriteString("\n")
+			b.WriteString(contract.diff)
+		}
+	}
+
+	return b.String()
+}
+
+func (r *accountsDiffResult) Oneliner() string {
+	differences := make([]string, 0)
+	for _, contract := range r.contracts {
+		if contract.status != contractIdentical {
+			differences = append(differences, fmt.Sprintf("%s: %s", contract.name, contract.status))
+		}
+	}
+	if len(differences) == 0 {
+		return "contracts are identical"
+	}
+
+	return strings.Join(differences, ", ")
+}
+
+// ExitCode is non-zero when any of the contracts differs between the accounts.
+func (r *accountsDiffResult) ExitCode() int {
+	for _, contract := range r.contracts {
+		if contract.status != contractIdentical {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,258 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/config"
+	"github.com/onflow/flowkit/v2/output"
+	"github.com/onflow/flowkit/v2/project"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsExportContracts struct {
+	Dir       string `default:"imports" flag:"dir" info:"Directory the contracts are written to"`
+	Register  bool   `default:"false" flag:"register" info:"Add the contracts to the configuration with an alias for the network"`
+	Overwrite bool   `default:"false" flag:"overwrite" info:"Overwrite existing contract files and contract locations in the configuration"`
+}
+
+var exportContractsFlags = flagsExportContracts{}
+
+var exportContractsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "export-contracts <address>",
+		Short: "Export the contracts deployed to an account into files",
+		Long: `Export the contracts deployed to an account into files.
+
+Each contract is written to a file named after the contract, with address imports converted to string imports.
+With the register flag the contracts are added to the configuration with an alias for the network,
+contracts already in the configuration with a different location are only updated with the overwrite flag.`,
+		Example: `flow accounts export-contracts 0x1654653399040a61 --dir ./imports --network mainnet --register`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &exportContractsFlags,
+	Run:   exportContracts,
+}
+
+func exportContracts(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	var state *flowkit.State
+	if exportContractsFlags.Register {
+		var err error
+		state, err = signer.Load(globalFlags.ConfigPaths, readerWriter)
+		if errors.Is(err, config.ErrDoesNotExist) {
+			return nil, fmt.Errorf("registering contracts requires a configuration, run 'flow init' to create one")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	address := flowsdk.HexToAddress(args[0])
+	network := flow.Network().Name
+
+	logger.StartProgress(fmt.Sprintf("Exporting contracts of account %s...", address))
+	defer logger.StopProgress()
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+	if len(account.Contracts) == 0 {
+		return nil, fmt.Errorf("no contracts deployed to account %s", address.HexWithPrefix())
+	}
+
+	names := make([]string, 0, len(account.Contracts))
+	for name := range account.Contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// all the files and contracts are checked first so nothing is written if any of them would be overwritten
+	files := make(map[string]string, len(names))
+	locations := make(map[string]string, len(names))
+	for _, name := range names {
+		location := filepath.Join(exportContractsFlags.Dir, fmt.Sprintf("%s.cdc", name))
+		if _, err := readerWriter.Stat(location); err == nil && !exportContractsFlags.Overwrite {
+			return nil, fmt.Errorf("file %s already exists, use --overwrite to replace it", location)
+		}
+		files[name] = location
+
+		if state == nil {
+			continue
+		}
+
+		// contract locations in the configuration are relative to the configuration file
+		configLocation, err := relativeToConfig(location, globalFlags.ConfigPaths)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := state.Contracts().ByName(name)
+		if err == nil && existing.Location != "" &&
+			filepath.Clean(existing.Location) != configLocation &&
+			!exportContractsFlags.Overwrite {
+			return nil, fmt.Errorf(
+				"contract %s is already in the configuration with location %s, use --overwrite to replace it",
+				name,
+				existing.Location,
+			)
+		}
+		locations[name] = configLocation
+	}
+
+	if err := readerWriter.MkdirAll(exportContractsFlags.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating directories: %w", err)
+	}
+
+	exported := make([]exportedContract, 0, len(names))
+	for _, name := range names {
+		program, err := project.NewProgram(account.Contracts[name], nil, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse contract %s: %w", name, err)
+		}
+		program.ConvertAddressImports()
+
+		location := files[name]
+		if err := readerWriter.WriteFile(location, program.CodeWithUnprocessedImports(), 0644); err != nil {
+			return nil, fmt.Errorf("error writing file: %w", err)
+		}
+
+		exported = append(exported, exportedContract{name: name, location: location})
+
+		if state != nil {
+			contract := config.Contract{Name: name}
+			if existing, err := state.Contracts().ByName(name); err == nil {
+				contract = *existing
+			}
+			contract.Location = locations[name]
+			for i := range contract.Aliases { // an existing alias for the network is replaced
+				if contract.Aliases[i].Network == network {
+					contract.Aliases[i].Address = address
+				}
+			}
+			contract.Aliases.Add(network, address)
+			state.Contracts().AddOrUpdate(contract)
+		}
+	}
+
+	if state != nil {
+		if err := state.SaveEdited(globalFlags.ConfigPaths); err != nil {
+			return nil, err
+		}
+	}
+
+	return &exportContractsResult{
+		address:    address,
+		network:    network,
+		contracts:  exported,
+		registered: state != nil,
+	}, nil
+}
+
+// relativeToConfig converts the location to a path relative to the configuration file the contracts are saved to.
+func relativeToConfig(location string, configPaths []string) (string, error) {
+	configDir := filepath.Dir(config.DefaultPath)
+	if !config.IsDefaultPath(configPaths) {
+		configDir = filepath.Dir(configPaths[0])
+	}
+
+	configDir, err := filepath.Abs(configDir)
+	if err != nil {
+		return "", err
+	}
+	location, err = filepath.Abs(location)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Rel(configDir, location)
+}
+
+type exportedContract struct {
+	name     string
+	location string
+}
+
+type exportContractsResult struct {
+	address    flowsdk.Address
+	network    string
+	contracts  []exportedContract
+	registered bool
+}
+
+func (r *exportContractsResult) JSON() any {
+	contracts := make([]any, 0, len(r.contracts))
+	for _, contract := range r.contracts {
+		contracts = append(contracts, map[string]any{
+			"name":     contract.name,
+			"location": contract.location,
+		})
+	}
+
+	return map[string]any{
+		"address":    r.address.HexWithPrefix(),
+		"network":    r.network,
+		"contracts":  contracts,
+		"registered": r.registered,
+	}
+}
+
+func (r *exportContractsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Exported %d contracts from %s on %s\n\n", len(r.contracts), r.address.HexWithPrefix(), r.network)
+	for _, contract := range r.contracts {
+		_, _ = fmt.Fprintf(writer, "  %s %s\t %s\n", output.SuccessEmoji(), contract.name, contract.location)
+	}
+	if r.registered {
+		_, _ = fmt.Fprintf(writer, "\nContracts added to the configuration with %s aliases\n", r.network)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *exportContractsResult) Oneliner() string {
+	locations := make([]string, 0, len(r.contracts))
+	for _, contract := range r.contracts {
+		locations = append(locations, contract.location)
+	}
+
+	return strings.Join(locations, ", ")
+}
@@ -19,32 +19,48 @@
 package accounts
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
 	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	flowGo "github.com/onflow/flow-go/model/flow"
 
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/config"
 	"github.com/onflow/flowkit/v2/output"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
 )
 
 type flagsFund struct {
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	Amount   string   `default:"1000.0" flag:"amount" info:"Amount of FLOW to fund an emulator account with"`
+	GasLimit uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Include  []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
 }
 
 var fundFlags = flagsFund{}
 
 var fundCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "fund <address>",
-		Short:   "Funds an account by address through the Testnet Faucet",
-		Example: "flow accounts fund 8e94eaa81771313a",
-		Args:    cobra.ExactArgs(1),
+		Use:   "fund <address|name>",
+		Short: "Funds an account by address through the Testnet Faucet or with the emulator service account",
+		Long: `Funds an account by address through the Testnet Faucet or with the emulator service account.
+
+On the testnet network the account is funded by opening the Testnet Faucet. On the emulator network the account
+is funded with FLOW minted by the emulator service account.`,
+		Example: `flow accounts fund 8e94eaa81771313a --network testnet
+
+flow accounts fund my-account --amount 100.0`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &fundFlags,
 	Run:   fund,
@@ -52,14 +68,36 @@ var fundCommand = &command.Command{
 
 func fund(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
-	_ flowkit.ReaderWriter,
+	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
+	// configuration is optional for the Testnet faucet and only needed to fund emulator accounts
+	state, err := signer.Load(globalFlags.ConfigPaths, readerWriter)
+	if err != nil && !errors.Is(err, config.ErrDoesNotExist) {
+		return nil, err
+	}
+
 	address := flowsdk.HexToAddress(args[0])
-	if !address.IsValid(flowsdk.Testnet) {
-		return nil, fmt.Errorf("unsupported address %s, faucet can only work for valid Testnet addresses", address.String())
+	if state != nil {
+		if account, err := state.Accounts().ByName(args[0]); err == nil {
+			address = account.Address
+		}
+	}
+
+	switch flow.Network().Name {
+	case config.EmulatorNetwork.Name:
+		if !address.IsValid(flowsdk.Emulator) {
+			return nil, fmt.Errorf("address %s is not valid on the emulator network, use the --network flag to fund accounts on other networks", address.String())
+		}
+		return fundEmulator(address, logger, state, flow)
+	case config.TestnetNetwork.Name:
+		if !address.IsValid(flowsdk.Testnet) {
+			return nil, fmt.Errorf("unsupported address %s, faucet can only work for valid Testnet addresses", address.String())
+		}
+	default:
+		return nil, fmt.Errorf("funding accounts is only supported on the emulator and testnet networks")
 	}
 
 	logger.Info(
@@ -78,3 +116,47 @@ func fund(
 
 	return nil, nil
 }
+
+// fundEmulator mints FLOW to the address with a transaction signed by the emulator service account.
+func fundEmulator(
+	address flowsdk.Address,
+	logger output.Logger,
+	state *flowkit.State,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if state == nil {
+		return nil, fmt.Errorf("funding emulator accounts requires a configuration with the emulator service account, run 'flow init' to create one")
+	}
+
+	serviceAccount, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := cadence.NewUFix64(fundFlags.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %s: %w", fundFlags.Amount, err)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Funding 0x%s with %s FLOW...", address, amount))
+	defer logger.StopProgress()
+
+	env := systemcontracts.SystemContractsForChain(flowGo.Emulator).AsTemplateEnv()
+	err = sendAccountTransaction(flow, serviceAccount, flowkit.Script{
+		Code: tmpl.GenerateMintFlowScript(env),
+		Args: []cadence.Value{cadence.NewAddress(address), amount},
+	}, fundFlags.GasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account: account,
+		include: fundFlags.Include,
+	}, nil
+}
@@ -0,0 +1,199 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/output"
+	"github.com/onflow/flowkit/v2/transactions"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsAddKey struct {
+	Key      string   `default:"" flag:"key" info:"Public key to add to the account"`
+	Weight   int      `default:"1000" flag:"weight" info:"Weight for the key"`
+	SigAlgo  string   `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the key"`
+	HashAlgo string   `default:"SHA3_256" flag:"hash-algo" info:"Hash used for the digest"`
+	GasLimit uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
+}
+
+var addKeyFlags = flagsAddKey{}
+
+var addKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "add-key <account>",
+		Short:   "Add a public key to an account from the configuration",
+		Example: `flow accounts add-key my-account --key d651f1931a2...8745 --weight 500`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &addKeyFlags,
+	RunS:  addKey,
+}
+
+func addKey(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if addKeyFlags.Key == "" {
+		return nil, fmt.Errorf("public key is required, use --key flag")
+	}
+
+	sigAlgos, err := parseSignatureAlgorithms([]string{addKeyFlags.SigAlgo})
+	if err != nil {
+		return nil, err
+	}
+
+	hashAlgos, err := parseHashingAlgorithms([]string{addKeyFlags.HashAlgo})
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeys, err := parsePublicKeys([]string{addKeyFlags.Key}, sigAlgos)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Adding key to account %s...", account.Address))
+	defer logger.StopProgress()
+
+	err = sendAddKey(flow, account, accounts.PublicKey{
+		Public:   pubKeys[0],
+		Weight:   addKeyFlags.Weight,
+		SigAlgo:  sigAlgos[0],
+		HashAlgo: hashAlgos[0],
+	}, addKeyFlags.GasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	onChain, err := flow.GetAccount(context.Background(), account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account: onChain,
+		include: addKeyFlags.Include,
+	}, nil
+}
+
+// sendAddKey adds the public key to the account with a transaction signed by the account.
+func sendAddKey(flow flowkit.Services, account *accounts.Account, key accounts.PublicKey, gasLimit uint64) error {
+	if key.Weight < 0 || key.Weight > flowsdk.AccountKeyWeightThreshold {
+		return fmt.Errorf("key weight must be between 0 and %d", flowsdk.AccountKeyWeightThreshold)
+	}
+
+	sigAlgo, err := sigAlgoRawValue(key.SigAlgo)
+	if err != nil {
+		return err
+	}
+
+	hashAlgo, err := hashAlgoRawValue(key.HashAlgo)
+	if err != nil {
+		return err
+	}
+
+	weight, err := cadence.NewUFix64(fmt.Sprintf("%d.0", key.Weight))
+	if err != nil {
+		return err
+	}
+
+	return sendAccountTransaction(flow, account, flowkit.Script{
+		Code: tmpl.GenerateAddKeyScript(tmpl.Environment{}),
+		Args: []cadence.Value{
+			cadence.String(strings.TrimPrefix(key.Public.String(), "0x")),
+			cadence.UInt8(sigAlgo),
+			cadence.UInt8(hashAlgo),
+			weight,
+		},
+	}, gasLimit)
+}
+
+// sendAccountTransaction sends the transaction signed by the account and returns an error if the transaction failed.
+func sendAccountTransaction(flow flowkit.Services, account *accounts.Account, script flowkit.Script, gasLimit uint64) error {
+	_, result, err := flow.SendTransaction(
+		context.Background(),
+		transactions.SingleAccountRole(*account),
+		script,
+		gasLimit,
+	)
+	if err != nil {
+		return err
+	}
+	if result != nil && result.Error != nil {
+		return fmt.Errorf("transaction failed: %w", result.Error)
+	}
+
+	return nil
+}
+
+// sigAlgoRawValue returns the raw value of the Cadence SignatureAlgorithm enum for the algorithm.
+func sigAlgoRawValue(algo crypto.SignatureAlgorithm) (uint8, error) {
+	switch algo {
+	case crypto.ECDSA_P256:
+		return 1, nil
+	case crypto.ECDSA_secp256k1:
+		return 2, nil
+	case crypto.BLS_BLS12_381:
+		return 3, nil
+	}
+
+	return 0, fmt.Errorf("unsupported signature algorithm: %s", algo)
+}
+
+// hashAlgoRawValue returns the raw value of the Cadence HashAlgorithm enum for the algorithm.
+func hashAlgoRawValue(algo crypto.HashAlgorithm) (uint8, error) {
+	switch algo {
+	case crypto.SHA2_256:
+		return 1, nil
+	case crypto.SHA2_384:
+		return 2, nil
+	case crypto.SHA3_256:
+		return 3, nil
+	case crypto.SHA3_384:
+		return 4, nil
+	case crypto.KMAC128:
+		return 5, nil
+	case crypto.Keccak256:
+		return 6, nil
+	}
+
+	return 0, fmt.Errorf("unsupported hash algorithm: %s", algo)
+}
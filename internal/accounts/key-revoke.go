@@ -0,0 +1,107 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsRevokeKey struct {
+	GasLimit uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
+}
+
+var revokeKeyFlags = flagsRevokeKey{}
+
+var revokeKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "revoke-key <account> <index>",
+		Short:   "Revoke a key of an account from the configuration",
+		Example: `flow accounts revoke-key my-account 1`,
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &revokeKeyFlags,
+	RunS:  revokeKey,
+}
+
+func revokeKey(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key index: %s", args[1])
+	}
+
+	// revoking the key used to sign would lock the configuration out of the account
+	if uint32(index) == account.Key.Index() {
+		return nil, fmt.Errorf(
+			"key %d is the key used by account %s in the configuration, use 'flow accounts rotate-key %s' to replace it",
+			index,
+			account.Name,
+			account.Name,
+		)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Revoking key %d of account %s...", index, account.Address))
+	defer logger.StopProgress()
+
+	err = sendRevokeKey(flow, account, uint32(index), revokeKeyFlags.GasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	onChain, err := flow.GetAccount(context.Background(), account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account: onChain,
+		include: revokeKeyFlags.Include,
+	}, nil
+}
+
+// sendRevokeKey revokes the key at the index with a transaction signed by the account.
+func sendRevokeKey(flow flowkit.Services, account *accounts.Account, index uint32, gasLimit uint64) error {
+	return sendAccountTransaction(flow, account, flowkit.Script{
+		Code: tmpl.GenerateRevokeKeyScript(tmpl.Environment{}),
+		Args: []cadence.Value{cadence.NewInt(int(index))},
+	}, gasLimit)
+}
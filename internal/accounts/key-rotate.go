@@ -0,0 +1,226 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/config"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/prompt"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRotateKey struct {
+	GasLimit uint64 `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var rotateKeyFlags = flagsRotateKey{}
+
+var rotateKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "rotate-key <account>",
+		Short: "Replace the key of an account from the configuration with a newly generated key",
+		Long: `Replace the key of an account from the configuration with a newly generated key.
+
+A new key is generated and added to the account, the configuration is updated to use the new key and after a
+transaction signed with the new key succeeds the old key is revoked.`,
+		Example: `flow accounts rotate-key my-account --network testnet`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &rotateKeyFlags,
+	RunS:  rotateKey,
+}
+
+// noopTransaction is signed with the new key to verify the account can be used with it before revoking the old key.
+const noopTransaction = `transaction {
+	prepare(signer: &Account) {}
+}`
+
+func rotateKey(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// the private key of other key types is managed outside the configuration
+	keyType := account.Key.Type()
+	if keyType != config.KeyTypeHex && keyType != config.KeyTypeFile {
+		return nil, fmt.Errorf(
+			"key rotation is only supported for accounts with hex or file keys, account %s uses a %s key",
+			account.Name,
+			account.Key.Type(),
+		)
+	}
+
+	oldIndex := account.Key.Index()
+	if !globalFlags.Yes && !prompt.GenericBoolPrompt(fmt.Sprintf(
+		"Replace key %d of account %s (0x%s) on network %s with a new key?",
+		oldIndex,
+		account.Name,
+		account.Address,
+		flow.Network().Name,
+	)) {
+		return nil, fmt.Errorf("key rotation was not approved")
+	}
+
+	sigAlgo := account.Key.SigAlgo()
+	hashAlgo := account.Key.HashAlgo()
+
+	newKey, err := flow.GenerateKey(context.Background(), sigAlgo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Adding new key to account %s...", account.Address))
+	err = sendAddKey(flow, account, accounts.PublicKey{
+		Public:   newKey.PublicKey(),
+		Weight:   flowsdk.AccountKeyWeightThreshold,
+		SigAlgo:  sigAlgo,
+		HashAlgo: hashAlgo,
+	}, rotateKeyFlags.GasLimit)
+	logger.StopProgress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to add the new key: %w", err)
+	}
+
+	onChain, err := flow.GetAccount(context.Background(), account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	newIndex, err := keyIndex(onChain, newKey.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	// the configuration is updated right away so the new key is not lost if any of the next steps fails
+	if keyType == config.KeyTypeFile {
+		location := account.Key.ToConfig().Location
+		err = state.ReaderWriter().WriteFile(location, []byte(newKey.String()), os.FileMode(0600))
+		if err != nil {
+			return nil, fmt.Errorf("failed saving private key: %w", err)
+		}
+		account.Key = &indexedFileKey{
+			FileKey: accounts.NewFileKey(location, newIndex, sigAlgo, hashAlgo, state.ReaderWriter()),
+		}
+	} else {
+		account.Key = accounts.NewHexKeyFromPrivateKey(newIndex, hashAlgo, newKey)
+	}
+	state.Accounts().AddOrUpdate(account)
+	if err := state.SaveDefault(); err != nil {
+		return nil, fmt.Errorf("failed to save the new key to the configuration: %w", err)
+	}
+	logger.Info(fmt.Sprintf("%s Key %d added and saved to the configuration", output.SuccessEmoji(), newIndex))
+
+	logger.StartProgress("Verifying the new key...")
+	err = sendAccountTransaction(flow, account, flowkit.Script{Code: []byte(noopTransaction)}, rotateKeyFlags.GasLimit)
+	logger.StopProgress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify the new key, the old key %d was not revoked: %w", oldIndex, err)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Revoking old key %d...", oldIndex))
+	err = sendRevokeKey(flow, account, oldIndex, rotateKeyFlags.GasLimit)
+	logger.StopProgress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke the old key %d, the new key %d is already in use: %w", oldIndex, newIndex, err)
+	}
+
+	return &rotateKeyResult{
+		account:   account,
+		oldIndex:  oldIndex,
+		newIndex:  newIndex,
+		publicKey: newKey.PublicKey(),
+	}, nil
+}
+
+// indexedFileKey is a file key that keeps its index when saved to the configuration.
+type indexedFileKey struct {
+	*accounts.FileKey
+}
+
+func (k *indexedFileKey) ToConfig() config.AccountKey {
+	conf := k.FileKey.ToConfig()
+	conf.Index = k.Index()
+	return conf
+}
+
+// keyIndex returns the index of the non-revoked account key matching the public key.
+func keyIndex(account *flowsdk.Account, publicKey crypto.PublicKey) (uint32, error) {
+	for _, key := range account.Keys {
+		if !key.Revoked && key.PublicKey.Equals(publicKey) {
+			return key.Index, nil
+		}
+	}
+
+	return 0, fmt.Errorf("new key was not found on account %s", account.Address)
+}
+
+type rotateKeyResult struct {
+	account   *accounts.Account
+	oldIndex  uint32
+	newIndex  uint32
+	publicKey crypto.PublicKey
+}
+
+func (r *rotateKeyResult) JSON() any {
+	return map[string]any{
+		"account":   r.account.Name,
+		"address":   r.account.Address.HexWithPrefix(),
+		"revoked":   r.oldIndex,
+		"index":     r.newIndex,
+		"publicKey": fmt.Sprintf("%x", r.publicKey.Encode()),
+	}
+}
+
+func (r *rotateKeyResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Key of account %s rotated\n\n", output.SuccessEmoji(), r.account.Name)
+	_, _ = fmt.Fprintf(writer, "Address\t %s\n", r.account.Address.HexWithPrefix())
+	_, _ = fmt.Fprintf(writer, "New Key Index\t %d\n", r.newIndex)
+	_, _ = fmt.Fprintf(writer, "Public Key\t %x\n", r.publicKey.Encode())
+	_, _ = fmt.Fprintf(writer, "Revoked Key Index\t %d\n", r.oldIndex)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *rotateKeyResult) Oneliner() string {
+	return fmt.Sprintf("Address: %s, Key Index: %d, Revoked Key Index: %d", r.account.Address.HexWithPrefix(), r.newIndex, r.oldIndex)
+}
@@ -0,0 +1,402 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsStorage struct{}
+
+var storageFlags = flagsStorage{}
+
+var storageCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "storage <address>",
+		Short: "Inspect the storage of an account",
+		Long: `Inspect the storage of an account.
+
+Shows the storage used and capacity, the stored paths with the types of the stored values, the public capabilities
+published by the account and the private capabilities issued from its storage or for the account itself.`,
+		Example: "flow accounts storage f8d6e0586b0a20c7",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &storageFlags,
+	Run:   storage,
+}
+
+// storageScript collects the storage usage, the stored paths, the published capabilities and
+// the capability controllers of the account.
+const storageScript = `
+access(all) struct StoredPath {
+	access(all) let path: StoragePath
+	access(all) let type: Type
+
+	init(path: StoragePath, type: Type) {
+		self.path = path
+		self.type = type
+	}
+}
+
+access(all) struct PublicCapability {
+	access(all) let path: PublicPath
+	access(all) let type: Type
+
+	init(path: PublicPath, type: Type) {
+		self.path = path
+		self.type = type
+	}
+}
+
+access(all) struct PrivateCapability {
+	access(all) let id: UInt64
+	access(all) let borrowType: Type
+	access(all) let target: StoragePath?
+	access(all) let tag: String
+
+	init(id: UInt64, borrowType: Type, target: StoragePath?, tag: String) {
+		self.id = id
+		self.borrowType = borrowType
+		self.target = target
+		self.tag = tag
+	}
+}
+
+access(all) struct StorageInfo {
+	access(all) let used: UInt64
+	access(all) let capacity: UInt64
+	access(all) let stored: [StoredPath]
+	access(all) let publicCapabilities: [PublicCapability]
+	access(all) let privateCapabilities: [PrivateCapability]
+
+	init(
+		used: UInt64,
+		capacity: UInt64,
+		stored: [StoredPath],
+		publicCapabilities: [PublicCapability],
+		privateCapabilities: [PrivateCapability]
+	) {
+		self.used = used
+		self.capacity = capacity
+		self.stored = stored
+		self.publicCapabilities = publicCapabilities
+		self.privateCapabilities = privateCapabilities
+	}
+}
+
+access(all) fun main(address: Address): StorageInfo {
+	let account = getAuthAccount<auth(Storage, Capabilities) &Account>(address)
+
+	let stored: [StoredPath] = []
+	let privateCapabilities: [PrivateCapability] = []
+	account.storage.forEachStored(fun (path: StoragePath, type: Type): Bool {
+		stored.append(StoredPath(path: path, type: type))
+		account.capabilities.storage.forEachController(forPath: path, fun (controller: &StorageCapabilityController): Bool {
+			privateCapabilities.append(PrivateCapability(
+				id: controller.capabilityID,
+				borrowType: controller.borrowType,
+				target: controller.target(),
+				tag: controller.tag
+			))
+			return true
+		})
+		return true
+	})
+
+	account.capabilities.account.forEachController(fun (controller: &AccountCapabilityController): Bool {
+		privateCapabilities.append(PrivateCapability(
+			id: controller.capabilityID,
+			borrowType: controller.borrowType,
+			target: nil,
+			tag: controller.tag
+		))
+		return true
+	})
+
+	let publicCapabilities: [PublicCapability] = []
+	account.storage.forEachPublic(fun (path: PublicPath, type: Type): Bool {
+		publicCapabilities.append(PublicCapability(path: path, type: type))
+		return true
+	})
+
+	return StorageInfo(
+		used: account.storage.used,
+		capacity: account.storage.capacity,
+		stored: stored,
+		publicCapabilities: publicCapabilities,
+		privateCapabilities: privateCapabilities
+	)
+}
+`
+
+func storage(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address := flowsdk.HexToAddress(args[0])
+
+	logger.StartProgress(fmt.Sprintf("Inspecting storage of account %s...", address))
+	defer logger.StopProgress()
+
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: []byte(storageScript),
+			Args: []cadence.Value{cadence.NewAddress(address)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error inspecting account storage: %w", err)
+	}
+
+	return newStorageResult(address, value)
+}
+
+type storedPath struct {
+	path string
+	typ  string
+}
+
+type publicCapability struct {
+	path       string
+	typ        string
+	borrowType string
+}
+
+type privateCapability struct {
+	id         uint64
+	borrowType string
+	target     string
+	tag        string
+}
+
+type storageResult struct {
+	address             flowsdk.Address
+	used                uint64
+	capacity            uint64
+	stored              []storedPath
+	publicCapabilities  []publicCapability
+	privateCapabilities []privateCapability
+}
+
+// newStorageResult converts the value returned by the storage script.
+func newStorageResult(address flowsdk.Address, value cadence.Value) (*storageResult, error) {
+	info, ok := value.(cadence.Struct)
+	if !ok {
+		return nil, fmt.Errorf("unexpected storage script result: %s", value)
+	}
+	fields := cadence.FieldsMappedByName(info)
+
+	result := &storageResult{
+		address:             address,
+		stored:              make([]storedPath, 0),
+		publicCapabilities:  make([]publicCapability, 0),
+		privateCapabilities: make([]privateCapability, 0),
+	}
+
+	if used, ok := fields["used"].(cadence.UInt64); ok {
+		result.used = uint64(used)
+	}
+	if capacity, ok := fields["capacity"].(cadence.UInt64); ok {
+		result.capacity = uint64(capacity)
+	}
+
+	for _, item := range structs(fields["stored"]) {
+		result.stored = append(result.stored, storedPath{
+			path: item["path"].String(),
+			typ:  typeID(item["type"]),
+		})
+	}
+
+	for _, item := range structs(fields["publicCapabilities"]) {
+		capability := publicCapability{
+			path: item["path"].String(),
+			typ:  typeID(item["type"]),
+		}
+		if typeValue, ok := item["type"].(cadence.TypeValue); ok {
+			if capabilityType, ok := typeValue.StaticType.(*cadence.CapabilityType); ok && capabilityType.BorrowType != nil {
+				capability.borrowType = capabilityType.BorrowType.ID()
+			}
+		}
+		result.publicCapabilities = append(result.publicCapabilities, capability)
+	}
+
+	for _, item := range structs(fields["privateCapabilities"]) {
+		capability := privateCapability{
+			borrowType: typeID(item["borrowType"]),
+		}
+		if id, ok := item["id"].(cadence.UInt64); ok {
+			capability.id = uint64(id)
+		}
+		if target, ok := item["target"].(cadence.Optional); ok && target.Value != nil {
+			capability.target = target.Value.String()
+		}
+		if tag, ok := item["tag"].(cadence.String); ok {
+			capability.tag = string(tag)
+		}
+		result.privateCapabilities = append(result.privateCapabilities, capability)
+	}
+
+	// iteration order of the account storage is not defined, so it's sorted for stable output
+	sort.Slice(result.stored, func(i, j int) bool {
+		return result.stored[i].path < result.stored[j].path
+	})
+	sort.Slice(result.publicCapabilities, func(i, j int) bool {
+		return result.publicCapabilities[i].path < result.publicCapabilities[j].path
+	})
+	sort.Slice(result.privateCapabilities, func(i, j int) bool {
+		return result.privateCapabilities[i].id < result.privateCapabilities[j].id
+	})
+
+	return result, nil
+}
+
+// structs returns the fields of the structs in the array value.
+func structs(value cadence.Value) []map[string]cadence.Value {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil
+	}
+
+	items := make([]map[string]cadence.Value, 0, len(array.Values))
+	for _, element := range array.Values {
+		if item, ok := element.(cadence.Struct); ok {
+			items = append(items, cadence.FieldsMappedByName(item))
+		}
+	}
+
+	return items
+}
+
+func typeID(value cadence.Value) string {
+	typeValue, ok := value.(cadence.TypeValue)
+	if !ok || typeValue.StaticType == nil {
+		return ""
+	}
+	return typeValue.StaticType.ID()
+}
+
+func (r *storageResult) JSON() any {
+	stored := make([]any, 0, len(r.stored))
+	for _, item := range r.stored {
+		stored = append(stored, map[string]any{
+			"path": item.path,
+			"type": item.typ,
+		})
+	}
+
+	public := make([]any, 0, len(r.publicCapabilities))
+	for _, item := range r.publicCapabilities {
+		public = append(public, map[string]any{
+			"path":       item.path,
+			"type":       item.typ,
+			"borrowType": item.borrowType,
+		})
+	}
+
+	private := make([]any, 0, len(r.privateCapabilities))
+	for _, item := range r.privateCapabilities {
+		capability := map[string]any{
+			"id":         item.id,
+			"borrowType": item.borrowType,
+			"tag":        item.tag,
+		}
+		if item.target != "" {
+			capability["target"] = item.target
+		}
+		private = append(private, capability)
+	}
+
+	return map[string]any{
+		"address":             r.address.HexWithPrefix(),
+		"used":                r.used,
+		"capacity":            r.capacity,
+		"stored":              stored,
+		"publicCapabilities":  public,
+		"privateCapabilities": private,
+	}
+}
+
+func (r *storageResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address\t %s\n", r.address.HexWithPrefix())
+	_, _ = fmt.Fprintf(writer, "Storage Used\t %d bytes\n", r.used)
+	_, _ = fmt.Fprintf(writer, "Storage Capacity\t %d bytes\n", r.capacity)
+
+	_, _ = fmt.Fprintf(writer, "\nStored Paths\t %d\n", len(r.stored))
+	for _, item := range r.stored {
+		_, _ = fmt.Fprintf(writer, "  %s\t %s\n", item.path, item.typ)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nPublic Capabilities\t %d\n", len(r.publicCapabilities))
+	for _, item := range r.publicCapabilities {
+		_, _ = fmt.Fprintf(writer, "  %s\t %s\n", item.path, item.borrowType)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nPrivate Capabilities\t %d\n", len(r.privateCapabilities))
+	for _, item := range r.privateCapabilities {
+		target := item.target
+		if target == "" {
+			target = "account"
+		}
+		tag := ""
+		if item.tag != "" {
+			tag = fmt.Sprintf(" (%s)", item.tag)
+		}
+		_, _ = fmt.Fprintf(writer, "  #%d %s\t %s%s\n", item.id, target, item.borrowType, tag)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *storageResult) Oneliner() string {
+	paths := make([]string, 0, len(r.stored))
+	for _, item := range r.stored {
+		paths = append(paths, item.path)
+	}
+
+	return fmt.Sprintf(
+		"Address: %s, Used: %d, Capacity: %d, Stored Paths: %s",
+		r.address.HexWithPrefix(),
+		r.used,
+		r.capacity,
+		strings.Join(paths, ", "),
+	)
+}
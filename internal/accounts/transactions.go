@@ -0,0 +1,305 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/transactions"
+)
+
+type flagsTransactions struct {
+	Start   uint64 `flag:"start" info:"Start block height"`
+	End     uint64 `flag:"end" info:"End block height"`
+	Last    uint64 `default:"10" flag:"last" info:"Scan number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
+	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when scanning blocks in parallel"`
+}
+
+var transactionsFlags = flagsTransactions{}
+
+var transactionsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "transactions <address>",
+		Short: "List transactions sent or authorized by an account in a block range",
+		Args:  cobra.ExactArgs(1),
+		Example: `#scan the latest 10 blocks is the default behavior
+flow accounts transactions f8d6e0586b0a20c7
+
+#scan the latest 100 blocks
+flow accounts transactions 1654653399040a61 --last 100 --network mainnet
+
+#specify manual start and end blocks
+flow accounts transactions 1654653399040a61 --start 11559500 --end 11559600 --network mainnet`,
+	},
+	Flags: &transactionsFlags,
+	Run:   listTransactions,
+}
+
+const (
+	rolePayer      = "payer"
+	roleProposer   = "proposer"
+	roleAuthorizer = "authorizer"
+)
+
+func listTransactions(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address := flowsdk.HexToAddress(args[0])
+	start := transactionsFlags.Start
+	end := transactionsFlags.End
+
+	// handle if not passing start and end
+	if start == 0 && end == 0 {
+		latest, err := flow.GetBlock(
+			context.Background(),
+			flowkit.BlockQuery{Latest: true},
+		)
+		if err != nil {
+			return nil, err
+		}
+		end = latest.Height
+
+		start = end - transactionsFlags.Last
+		if end < transactionsFlags.Last {
+			start = 0
+		}
+	} else if start == 0 || end == 0 {
+		return nil, fmt.Errorf("please provide either both start and end for range or only last flag")
+	}
+
+	if end < start {
+		return nil, fmt.Errorf("cannot have end height (%d) of block range less that start height (%d)", end, start)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Scanning blocks %d to %d for transactions of %s...", start, end, address))
+	defer logger.StopProgress()
+
+	found, err := scanTransactions(context.Background(), flow, address, start, end, transactionsFlags.Workers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountTransactionsResult{
+		address:      address,
+		transactions: found,
+	}, nil
+}
+
+// accountTransaction is a transaction in which the account takes part in at least one of the roles.
+type accountTransaction struct {
+	height uint64
+	roles  []string
+	tx     *flowsdk.Transaction
+	result *flowsdk.TransactionResult
+}
+
+type blockScanResult struct {
+	transactions []accountTransaction
+	err          error
+}
+
+// scanTransactions fetches the blocks in the range together with their collections and transactions using a pool
+// of workers, and returns the transactions matching the address as payer, proposer or authorizer.
+func scanTransactions(
+	ctx context.Context,
+	flow flowkit.Services,
+	address flowsdk.Address,
+	start uint64,
+	end uint64,
+	workers int,
+) ([]accountTransaction, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan uint64, workers)
+	results := make(chan blockScanResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range jobs {
+				found, err := scanBlock(ctx, flow, address, height)
+				results <- blockScanResult{found, err}
+			}
+		}()
+	}
+
+	// wait on the workers to finish and close the result channel
+	// to signal downstream that all work is done
+	go func() {
+		defer close(results)
+		wg.Wait()
+	}()
+
+	go func() {
+		defer close(jobs)
+		for height := start; height <= end; height++ {
+			select {
+			case jobs <- height:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var err error
+	found := make([]accountTransaction, 0)
+	for result := range results {
+		if result.err != nil && err == nil {
+			err = result.err
+			cancel()
+		}
+		found = append(found, result.transactions...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// most recent transactions first
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].height > found[j].height
+	})
+
+	return found, nil
+}
+
+func scanBlock(
+	ctx context.Context,
+	flow flowkit.Services,
+	address flowsdk.Address,
+	height uint64,
+) ([]accountTransaction, error) {
+	block, err := flow.GetBlock(ctx, flowkit.BlockQuery{Height: height})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block at height %d: %w", height, err)
+	}
+
+	found := make([]accountTransaction, 0)
+	for _, guarantee := range block.CollectionGuarantees {
+		collection, err := flow.GetCollection(ctx, guarantee.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get collection %s: %w", guarantee.CollectionID, err)
+		}
+
+		for _, id := range collection.TransactionIDs {
+			tx, result, err := flow.GetTransactionByID(ctx, id, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get transaction %s: %w", id, err)
+			}
+
+			roles := transactionRoles(tx, address)
+			if len(roles) == 0 {
+				continue
+			}
+
+			found = append(found, accountTransaction{
+				height: block.Height,
+				roles:  roles,
+				tx:     tx,
+				result: result,
+			})
+		}
+	}
+
+	return found, nil
+}
+
+// transactionRoles returns the roles the address has in the transaction.
+func transactionRoles(tx *flowsdk.Transaction, address flowsdk.Address) []string {
+	roles := make([]string, 0)
+	if tx.Payer == address {
+		roles = append(roles, rolePayer)
+	}
+	if tx.ProposalKey.Address == address {
+		roles = append(roles, roleProposer)
+	}
+	for _, authorizer := range tx.Authorizers {
+		if authorizer == address {
+			roles = append(roles, roleAuthorizer)
+			break
+		}
+	}
+
+	return roles
+}
+
+type accountTransactionsResult struct {
+	address      flowsdk.Address
+	transactions []accountTransaction
+}
+
+func (r *accountTransactionsResult) JSON() any {
+	result := make([]any, 0, len(r.transactions))
+	for _, found := range r.transactions {
+		txResult := transactions.NewTransactionResult(found.tx, found.result).JSON()
+		if txJSON, ok := txResult.(map[string]any); ok {
+			txJSON["roles"] = found.roles
+		}
+		result = append(result, txResult)
+	}
+
+	return result
+}
+
+func (r *accountTransactionsResult) String() string {
+	if len(r.transactions) == 0 {
+		return fmt.Sprintf("No transactions found for account %s", r.address)
+	}
+
+	var b strings.Builder
+	for i, found := range r.transactions {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		_, _ = fmt.Fprintf(&b, "Roles\t%s\n", strings.Join(found.roles, ", "))
+		b.WriteString(transactions.NewTransactionResult(found.tx, found.result).String())
+	}
+
+	return b.String()
+}
+
+func (r *accountTransactionsResult) Oneliner() string {
+	ids := make([]string, 0, len(r.transactions))
+	for _, found := range r.transactions {
+		ids = append(ids, found.tx.ID().String())
+	}
+
+	return strings.Join(ids, ", ")
+}
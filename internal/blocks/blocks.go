@@ -44,6 +44,7 @@ func init() {
 type blockResult struct {
 	block       *flow.Block
 	events      []flow.BlockEvents
+	eventType   string
 	collections []*flow.Collection
 	included    []string
 }
@@ -73,6 +74,12 @@ func (r *blockResult) JSON() any {
 	}
 
 	result["collection"] = collections
+
+	if r.eventType != "" {
+		e := events.EventResult{BlockEvents: r.events}
+		result["events"] = e.JSON()
+	}
+
 	return result
 }
 
@@ -98,4 +98,7 @@ Total Collections	3
 		},
 		result.JSON(),
 	)
+
+	result.eventType = "flow.AccountCreated"
+	assert.Equal(t, []any{}, result.JSON().(map[string]any)["events"])
 }
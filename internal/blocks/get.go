@@ -96,6 +96,7 @@ func get(
 	return &blockResult{
 		block:       block,
 		events:      events,
+		eventType:   blockFlags.Events,
 		collections: collections,
 		included:    blockFlags.Include,
 	}, nil
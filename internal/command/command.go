@@ -48,6 +48,7 @@ import (
 
 	"github.com/onflow/flow-cli/build"
 	"github.com/onflow/flow-cli/internal/settings"
+	"github.com/onflow/flow-cli/internal/signer"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -82,6 +83,11 @@ const (
 	FormatJSON   = "json"
 )
 
+const (
+	DecodeCadence = "cadence"
+	DecodeNative  = "native"
+)
+
 const (
 	logLevelDebug = "debug"
 	logLevelInfo  = "info"
@@ -107,7 +113,7 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		loader := &afero.Afero{Fs: afero.NewOsFs()}
 
 		// if we receive a config error that isn't missing config we should handle it
-		state, confErr := flowkit.Load(Flags.ConfigPaths, loader)
+		state, confErr := signer.Load(Flags.ConfigPaths, loader)
 		if !errors.Is(confErr, config.ErrDoesNotExist) {
 			handleError("Config Error", confErr)
 		}
@@ -146,6 +152,11 @@ func (c Command) AddToParent(parent *cobra.Command) {
 			panic("command implementation needs to provide run functionality")
 		}
 
+		// release the resources held by the account keys, like token sessions
+		if closeErr := signer.Close(state); err == nil {
+			err = closeErr
+		}
+
 		handleError("Command Error", err)
 
 		// Do not print a result if none is provided.
@@ -157,7 +168,7 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		}
 
 		// format output result
-		formattedResult, err := formatResult(result, Flags.Filter, Flags.Format)
+		formattedResult, err := formatResult(result, Flags.Filter, Flags.Format, Flags.Decode)
 		handleError("Result", err)
 
 		// output result
@@ -378,6 +389,7 @@ func UsageMetrics(command *cobra.Command, wg *sync.WaitGroup) {
 type GlobalFlags struct {
 	Filter           string
 	Format           string
+	Decode           string
 	Save             string
 	Host             string
 	HostNetworkKey   string
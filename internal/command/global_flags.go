@@ -34,6 +34,7 @@ import (
 var Flags = GlobalFlags{
 	Filter:           "",
 	Format:           FormatText,
+	Decode:           DecodeCadence,
 	Save:             "",
 	Host:             "",
 	HostNetworkKey:   "",
@@ -86,6 +87,14 @@ func InitFlags(cmd *cobra.Command) {
 		"Output format, options: \"text\", \"json\", \"inline\"",
 	)
 
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Decode,
+		"decode",
+		"",
+		Flags.Decode,
+		"Decoding of Cadence values in JSON output, options: \"cadence\" (JSON-Cadence), \"native\" (plain JSON, requires \"--output json\" or \"--filter\")",
+	)
+
 	cmd.PersistentFlags().StringVarP(
 		&Flags.Save,
 		"save",
@@ -39,6 +39,7 @@ func TestInitFlags(t *testing.T) {
 	}{
 		{"filter", command.Flags.Filter},
 		{"format", command.Flags.Format},
+		{"decode", command.Flags.Decode},
 		{"save", command.Flags.Save},
 		{"host", command.Flags.Host},
 		{"network-key", command.Flags.HostNetworkKey},
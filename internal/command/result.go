@@ -32,6 +32,8 @@ import (
 
 	"github.com/onflow/flowkit/v2/config"
 	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 // Result interface describes all the formats for the result output.
@@ -61,11 +63,23 @@ func ContainsFlag(flags []string, field string) bool {
 }
 
 // formatResult formats a result for printing.
-func formatResult(result Result, filterFlag string, formatFlag string) (string, error) {
+func formatResult(result Result, filterFlag string, formatFlag string, decodeFlag string) (string, error) {
 	if result == nil {
 		return "", fmt.Errorf("missing result")
 	}
 
+	switch strings.ToLower(decodeFlag) {
+	case DecodeCadence, "":
+	case DecodeNative:
+		// native decoding only changes the JSON values, which are used by the JSON output and the filter
+		if filterFlag == "" && strings.ToLower(formatFlag) != FormatJSON {
+			return "", fmt.Errorf("decode option %s is only supported with JSON output or a filter", DecodeNative)
+		}
+		result = &nativeResult{result}
+	default:
+		return "", fmt.Errorf("unsupported decode option %s, use %s or %s", decodeFlag, DecodeCadence, DecodeNative)
+	}
+
 	if filterFlag != "" {
 		value, err := filterResultValue(result, filterFlag)
 		if err != nil {
@@ -86,6 +100,15 @@ func formatResult(result Result, filterFlag string, formatFlag string) (string,
 	}
 }
 
+// nativeResult outputs the JSON-Cadence values of the result as plain JSON.
+type nativeResult struct {
+	Result
+}
+
+func (r *nativeResult) JSON() any {
+	return util.NativeJSON(r.Result.JSON())
+}
+
 // outputResult to selected media.
 func outputResult(result string, saveFlag string, formatFlag string, filterFlag string) error {
 	if saveFlag != "" {
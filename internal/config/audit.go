@@ -0,0 +1,409 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/config"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsAudit struct {
+	Offline     bool `default:"false" flag:"offline" info:"Skip comparing configured keys with the on-chain account keys"`
+	SkipHistory bool `default:"false" flag:"skip-history" info:"Skip scanning the git history for committed keys"`
+}
+
+var auditFlags = flagsAudit{}
+
+var auditCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "audit",
+		Short: "Audit the configuration for exposed or mismatched account keys",
+		Long: "Audit the keys of accounts outside the emulator for plaintext keys in the configuration, " +
+			"key files missing from .gitignore, keys committed to the git history and keys not matching the " +
+			"on-chain account keys on the selected network.",
+		Example: "flow config audit --network testnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &auditFlags,
+	RunS:  audit,
+}
+
+const (
+	checkPlaintextKey = "plaintext-key"
+	checkGitIgnore    = "gitignore"
+	checkGitHistory   = "git-history"
+	checkOnChainKey   = "on-chain-key"
+)
+
+const (
+	severityHigh   = "high"
+	severityMedium = "medium"
+)
+
+// maxAuditBlobSize limits the size of files scanned in the git history.
+const maxAuditBlobSize = 1 << 20
+
+type auditFinding struct {
+	check    string
+	severity string
+	account  string
+	message  string
+	fix      string
+}
+
+// auditSecret is a private key or mnemonic of an account searched for in the git history.
+type auditSecret struct {
+	account string
+	value   string
+}
+
+func audit(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	findings := make([]auditFinding, 0)
+
+	rawConfig := ""
+	configDir := "."
+	for _, path := range globalFlags.ConfigPaths {
+		content, err := state.ReadFile(path)
+		if err == nil {
+			rawConfig += string(content)
+			// the last loaded configuration overrides the previous ones, like the local over the global one
+			configDir = filepath.Dir(path)
+		}
+	}
+
+	secrets := make([]auditSecret, 0)
+	for i := range *state.Accounts() {
+		account := &(*state.Accounts())[i]
+		if account.Address.IsValid(flowsdk.Emulator) {
+			continue // emulator keys are not sensitive
+		}
+
+		keyConfig := account.Key.ToConfig()
+		secret := accountSecret(account)
+		if secret != "" {
+			secrets = append(secrets, auditSecret{account: account.Name, value: secret})
+		}
+
+		switch account.Key.Type() {
+		case config.KeyTypeHex, config.KeyTypeBip44:
+			// keys provided with environment variables are not stored in the configuration
+			if secret != "" && strings.Contains(rawConfig, secret) {
+				findings = append(findings, auditFinding{
+					check:    checkPlaintextKey,
+					severity: severityHigh,
+					account:  account.Name,
+					message:  fmt.Sprintf("%s key of account %s is stored in plaintext in the configuration", keyConfig.Type, account.Name),
+					fix: fmt.Sprintf(
+						`move the key to a file ignored by git and reference it with "key": {"type": "file", "location": "%s.pkey"}`,
+						account.Name,
+					),
+				})
+			}
+		case config.KeyTypeFile:
+			// key file locations are relative to the configuration
+			location := keyConfig.Location
+			if !filepath.IsAbs(location) {
+				location = filepath.Join(configDir, location)
+			}
+
+			ignored, err := util.IsGitIgnored(keyConfig.Location, configDir, state.ReaderWriter())
+			if err != nil {
+				return nil, fmt.Errorf("failed to check .gitignore: %w", err)
+			}
+			if !ignored {
+				findings = append(findings, auditFinding{
+					check:    checkGitIgnore,
+					severity: severityMedium,
+					account:  account.Name,
+					message:  fmt.Sprintf("key file %s of account %s is not in .gitignore", location, account.Name),
+					fix: fmt.Sprintf(
+						"add %s to %s",
+						filepath.ToSlash(keyConfig.Location),
+						filepath.ToSlash(filepath.Join(configDir, ".gitignore")),
+					),
+				})
+			}
+		}
+	}
+
+	if !auditFlags.SkipHistory && len(secrets) > 0 {
+		logger.StartProgress("Scanning git history...")
+		repo, err := git.PlainOpenWithOptions(configDir, &git.PlainOpenOptions{DetectDotGit: true})
+		if err == nil {
+			historyFindings, err := auditGitHistory(repo, secrets)
+			if err != nil {
+				logger.StopProgress()
+				return nil, fmt.Errorf("failed to scan git history: %w", err)
+			}
+			findings = append(findings, historyFindings...)
+		} else if !errors.Is(err, git.ErrRepositoryNotExists) {
+			logger.StopProgress()
+			return nil, fmt.Errorf("failed to open git repository: %w", err)
+		}
+		logger.StopProgress()
+	}
+
+	if !auditFlags.Offline {
+		logger.StartProgress(fmt.Sprintf("Comparing account keys on %s...", flow.Network().Name))
+		onChainFindings := auditOnChainKeys(flow, state)
+		logger.StopProgress()
+		findings = append(findings, onChainFindings...)
+	}
+
+	return &auditResult{findings: findings}, nil
+}
+
+// accountSecret returns the hex private key or mnemonic of the account, or empty if not accessible.
+func accountSecret(account *accounts.Account) string {
+	keyType := account.Key.Type()
+	if keyType == config.KeyTypeBip44 {
+		return account.Key.ToConfig().Mnemonic
+	}
+
+	if keyType != config.KeyTypeHex && keyType != config.KeyTypeFile {
+		return ""
+	}
+
+	privateKey, err := account.Key.PrivateKey()
+	if err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString((*privateKey).Encode())
+}
+
+// auditGitHistory searches all files in all commits for the secrets, each blob is only scanned once.
+func auditGitHistory(repo *git.Repository, secrets []auditSecret) ([]auditFinding, error) {
+	commits, err := repo.Log(&git.LogOptions{All: true})
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil // no commits yet
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]auditFinding, 0)
+	scanned := make(map[plumbing.Hash]bool)
+	found := make(map[string]bool)
+
+	values := make([][]byte, len(secrets))
+	for i, secret := range secrets {
+		values[i] = []byte(strings.ToLower(secret.value))
+	}
+
+	err = commits.ForEach(func(commit *object.Commit) error {
+		files, err := commit.Files()
+		if err != nil {
+			return err
+		}
+
+		return files.ForEach(func(file *object.File) error {
+			if scanned[file.Hash] || file.Size > maxAuditBlobSize {
+				return nil
+			}
+			scanned[file.Hash] = true
+
+			reader, err := file.Reader()
+			if err != nil {
+				return err
+			}
+			content, err := io.ReadAll(reader)
+			_ = reader.Close()
+			if err != nil {
+				return err
+			}
+			content = bytes.ToLower(content)
+
+			for i, secret := range secrets {
+				if found[secret.account] || !bytes.Contains(content, values[i]) {
+					continue
+				}
+
+				found[secret.account] = true
+				findings = append(findings, auditFinding{
+					check:    checkGitHistory,
+					severity: severityHigh,
+					account:  secret.account,
+					message: fmt.Sprintf(
+						"key of account %s is committed in %s at commit %s",
+						secret.account,
+						file.Name,
+						commit.Hash.String()[:7],
+					),
+					fix: fmt.Sprintf(
+						"consider the key compromised and replace it with 'flow accounts rotate-key %s', removing the file from git doesn't remove it from the history",
+						secret.account,
+					),
+				})
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findings, nil
+}
+
+// auditOnChainKeys compares the configured keys of accounts on the current network with the on-chain keys.
+func auditOnChainKeys(flow flowkit.Services, state *flowkit.State) []auditFinding {
+	findings := make([]auditFinding, 0)
+	network := flow.Network().Name
+
+	for i := range *state.Accounts() {
+		account := &(*state.Accounts())[i]
+		if account.Address.IsValid(flowsdk.Emulator) {
+			continue
+		}
+
+		chain, err := util.GetAddressNetwork(account.Address)
+		if err != nil || strings.TrimPrefix(chain.String(), "flow-") != network {
+			continue // account belongs to a different network
+		}
+
+		privateKey, err := account.Key.PrivateKey()
+		if err != nil {
+			continue // keys without private key access (e.g. KMS) can't be compared
+		}
+		publicKey := (*privateKey).PublicKey()
+
+		onChain, err := flow.GetAccount(context.Background(), account.Address)
+		if err != nil {
+			findings = append(findings, auditFinding{
+				check:    checkOnChainKey,
+				severity: severityMedium,
+				account:  account.Name,
+				message:  fmt.Sprintf("account %s could not be fetched from %s: %s", account.Name, network, err),
+				fix:      "check the account address and network in the configuration",
+			})
+			continue
+		}
+
+		index := account.Key.Index()
+		var matching *flowsdk.AccountKey
+		for _, key := range onChain.Keys {
+			if key.PublicKey.Equals(publicKey) && !key.Revoked {
+				matching = key
+				if key.Index == index {
+					break
+				}
+			}
+		}
+
+		switch {
+		case matching == nil:
+			findings = append(findings, auditFinding{
+				check:    checkOnChainKey,
+				severity: severityMedium,
+				account:  account.Name,
+				message:  fmt.Sprintf("configured key of account %s is not an active key of 0x%s on %s", account.Name, account.Address.Hex(), network),
+				fix:      fmt.Sprintf("update the key of %s in the configuration or add it to the account with 'flow accounts add-key'", account.Name),
+			})
+		case matching.Index != index:
+			findings = append(findings, auditFinding{
+				check:    checkOnChainKey,
+				severity: severityMedium,
+				account:  account.Name,
+				message:  fmt.Sprintf("configured key index %d of account %s doesn't match the on-chain key index %d", index, account.Name, matching.Index),
+				fix:      fmt.Sprintf(`set "index": %d for the key of %s in the configuration`, matching.Index, account.Name),
+			})
+		}
+	}
+
+	return findings
+}
+
+type auditResult struct {
+	findings []auditFinding
+}
+
+func (r *auditResult) JSON() any {
+	findings := make([]map[string]string, 0, len(r.findings))
+	for _, finding := range r.findings {
+		findings = append(findings, map[string]string{
+			"check":    finding.check,
+			"severity": finding.severity,
+			"account":  finding.account,
+			"message":  finding.message,
+			"fix":      finding.fix,
+		})
+	}
+
+	return findings
+}
+
+func (r *auditResult) String() string {
+	if len(r.findings) == 0 {
+		return fmt.Sprintf("%s No issues found\n", output.OkEmoji())
+	}
+
+	var b bytes.Buffer
+	for _, finding := range r.findings {
+		_, _ = fmt.Fprintf(&b, "%s [%s] %s\n", output.ErrorEmoji(), finding.severity, finding.message)
+		_, _ = fmt.Fprintf(&b, "   Fix: %s\n\n", finding.fix)
+	}
+	_, _ = fmt.Fprintf(&b, "%d issues found\n", len(r.findings))
+
+	return b.String()
+}
+
+func (r *auditResult) Oneliner() string {
+	messages := make([]string, 0, len(r.findings))
+	for _, finding := range r.findings {
+		messages = append(messages, finding.message)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+func (r *auditResult) ExitCode() int {
+	if len(r.findings) > 0 {
+		return 1
+	}
+
+	return 0
+}
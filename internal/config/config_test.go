@@ -0,0 +1,95 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flowkit/v2/accounts"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Audit(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	auditFlags.Offline = true
+	auditFlags.SkipHistory = true
+
+	addresses := flow.NewAddressGenerator(flow.Testnet)
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+	require.NoError(t, err)
+
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    "alice",
+		Address: addresses.NextAddress(),
+		Key:     accounts.NewHexKeyFromPrivateKey(0, crypto.SHA3_256, privateKey),
+	})
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    "bob",
+		Address: addresses.NextAddress(),
+		Key:     accounts.NewFileKey("bob.pkey", 0, crypto.ECDSA_P256, crypto.SHA3_256, rw),
+	})
+	require.NoError(t, state.Save("project/flow.json"))
+
+	flags := command.GlobalFlags{ConfigPaths: []string{"project/flow.json"}}
+
+	t.Run("Findings", func(t *testing.T) {
+		result, err := audit([]string{}, flags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		findings := result.(*auditResult).findings
+		require.Len(t, findings, 2)
+
+		assert.Equal(t, checkPlaintextKey, findings[0].check)
+		assert.Equal(t, severityHigh, findings[0].severity)
+		assert.Equal(t, "alice", findings[0].account)
+		assert.Equal(t, "hex key of account alice is stored in plaintext in the configuration", findings[0].message)
+
+		assert.Equal(t, checkGitIgnore, findings[1].check)
+		assert.Equal(t, severityMedium, findings[1].severity)
+		assert.Equal(t, "bob", findings[1].account)
+		assert.Equal(t, "key file project/bob.pkey of account bob is not in .gitignore", findings[1].message)
+		assert.Equal(t, "add bob.pkey to project/.gitignore", findings[1].fix)
+
+		assert.Equal(t, 1, result.(*auditResult).ExitCode())
+	})
+
+	t.Run("Ignored key file", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, rw.WriteFile(filepath.Join(wd, ".gitignore"), []byte("flow.json\n"), 0644))
+		require.NoError(t, rw.WriteFile(filepath.Join(wd, "project", ".gitignore"), []byte("*.pkey\n"), 0644))
+
+		result, err := audit([]string{}, flags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		findings := result.(*auditResult).findings
+		require.Len(t, findings, 1)
+		assert.Equal(t, checkPlaintextKey, findings[0].check)
+	})
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/onflow/flowkit/v2/config"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -72,7 +73,7 @@ func configuredServiceKey(
 			}
 		}
 	} else {
-		state, err = flowkit.Load(command.Flags.ConfigPaths, loader)
+		state, err = signer.Load(command.Flags.ConfigPaths, loader)
 		if err != nil {
 			if errors.Is(err, config.ErrDoesNotExist) {
 				exitf(1, "🙏 Configuration is missing, initialize it with: 'flow init' and then rerun this command.")
@@ -110,4 +110,26 @@ Events Block #1:
 		"type":          "A.foo",
 		"values":        json.RawMessage{0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x69, 0x64, 0x22, 0x3a, 0x22, 0x41, 0x2e, 0x66, 0x6f, 0x6f, 0x22, 0x2c, 0x22, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x3a, 0x5b, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x22, 0x31, 0x22, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x49, 0x6e, 0x74, 0x22, 0x7d, 0x2c, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x22, 0x62, 0x61, 0x72, 0x22, 0x7d, 0x5d, 0x7d, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x7d, 0xa},
 	}}, event.JSON())
+
+	deposit := EventResult{
+		BlockEvents: []flow.BlockEvents{{
+			Height: block.Height,
+			Events: []flow.Event{
+				*tests.NewEvent(
+					0,
+					"A.0000000000000001.Foo.Bar",
+					[]cadence.Field{{Type: cadence.UFix64Type, Identifier: "amount"}},
+					[]cadence.Value{cadence.UFix64(100000000)},
+				),
+			},
+		}},
+	}
+
+	assert.Equal(t, []any{map[string]any{
+		"blockID":       uint64(1),
+		"index":         0,
+		"transactionId": "0000000000000000000000000000000000000000000000000000000000000000",
+		"type":          "A.0000000000000001.Foo.Bar",
+		"values":        map[string]any{"amount": "1.00000000"},
+	}}, util.NativeJSON(deposit.JSON()))
 }
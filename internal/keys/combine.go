@@ -0,0 +1,132 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsCombine struct {
+	PublicKey string `default:"" flag:"public-key" info:"Expected public key of the reconstructed private key"`
+	FromFile  string `default:"" flag:"from-file" info:"Load shares from file, one share per line"`
+}
+
+var combineFlags = flagsCombine{}
+
+var combineCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "combine <share> <share>... --public-key <public key>",
+		Short:   "Reconstruct a private key from Shamir secret shares",
+		Example: "flow keys combine flow-share:v1:3:1:ECDSA_P256:SHA3_256:... flow-share:v1:3:4:ECDSA_P256:SHA3_256:... --public-key 84d716c14b0...",
+	},
+	Flags: &combineFlags,
+	Run:   combine,
+}
+
+func combine(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	reader flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	if combineFlags.PublicKey == "" {
+		return nil, fmt.Errorf("provide the expected public key with the public-key flag")
+	}
+
+	encoded := args
+	if combineFlags.FromFile != "" {
+		content, err := reader.ReadFile(combineFlags.FromFile)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range strings.Split(string(content), "\n") {
+			if strings.TrimSpace(line) != "" {
+				encoded = append(encoded, line)
+			}
+		}
+	}
+
+	if len(encoded) < 2 {
+		return nil, fmt.Errorf("at least two shares are required")
+	}
+
+	shares := make([]*keyShare, len(encoded))
+	for i, e := range encoded {
+		share, err := parseKeyShare(e)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		shares[i] = share
+	}
+
+	indexes := make([]byte, len(shares))
+	data := make([][]byte, len(shares))
+	seen := make(map[byte]bool)
+	for i, share := range shares {
+		if share.threshold != shares[0].threshold ||
+			share.sigAlgo != shares[0].sigAlgo ||
+			share.hashAlgo != shares[0].hashAlgo ||
+			len(share.data) != len(shares[0].data) {
+			return nil, fmt.Errorf("share %d doesn't belong to the same key as share 1", i+1)
+		}
+		if seen[share.index] {
+			return nil, fmt.Errorf("share with index %d is provided more than once", share.index)
+		}
+
+		seen[share.index] = true
+		indexes[i] = share.index
+		data[i] = share.data
+	}
+
+	if len(shares) < shares[0].threshold {
+		return nil, fmt.Errorf("%d shares are required to reconstruct the key, got %d", shares[0].threshold, len(shares))
+	}
+
+	privateKey, err := crypto.DecodePrivateKey(shares[0].sigAlgo, combineSecret(indexes, data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct private key: %w", err)
+	}
+
+	expected, err := crypto.DecodePublicKeyHex(shares[0].sigAlgo, strings.TrimPrefix(combineFlags.PublicKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	}
+
+	if !privateKey.PublicKey().Equals(expected) {
+		return nil, fmt.Errorf("reconstructed private key doesn't match the expected public key")
+	}
+
+	return &keyResult{
+		privateKey: privateKey,
+		publicKey:  privateKey.PublicKey(),
+		sigAlgo:    privateKey.Algorithm(),
+		hashAlgo:   shares[0].hashAlgo,
+	}, nil
+}
@@ -0,0 +1,496 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsConvert struct {
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of hex encoded keys"`
+	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hashing algorithm used for RLP encoded account keys"`
+	Weight   int    `default:"1000" flag:"weight" info:"Weight used for RLP encoded account keys"`
+	FromFile string `default:"" flag:"from-file" info:"Load key from file"`
+}
+
+var convertFlags = flagsConvert{}
+
+var convertCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:       "convert <hex|pem|jwk|rlp> <hex|pem|jwk|rlp> <encoded key>",
+		Short:     "Convert a private or public key between encodings",
+		Args:      cobra.RangeArgs(2, 3),
+		ValidArgs: []string{formatHex, formatPEM, formatJWK, formatRLP},
+		Example:   "flow keys convert pem jwk --from-file ./key.pem",
+	},
+	Flags: &convertFlags,
+	Run:   convert,
+}
+
+const (
+	formatHex = "hex"
+	formatPEM = "pem"
+	formatJWK = "jwk"
+	formatRLP = "rlp"
+)
+
+const (
+	pemPrivateKey   = "PRIVATE KEY"
+	pemECPrivateKey = "EC PRIVATE KEY"
+	pemPublicKey    = "PUBLIC KEY"
+)
+
+var (
+	oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidP256        = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidSecp256k1   = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// asn.1 structures of PKCS#8 (RFC 5208), SEC1 (RFC 5915) and SPKI (RFC 5280) EC keys.
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	NamedCurve asn1.ObjectIdentifier
+}
+
+type pkcs8PrivateKey struct {
+	Version    int
+	Algorithm  algorithmIdentifier
+	PrivateKey []byte
+}
+
+type ecPrivateKey struct {
+	Version    int
+	PrivateKey []byte
+	NamedCurve asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey  asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+type subjectPublicKeyInfo struct {
+	Algorithm algorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	D   string `json:"d,omitempty"`
+}
+
+// convertedKey holds the decoded key, the private key is nil for public keys.
+type convertedKey struct {
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+	hashAlgo   crypto.HashAlgorithm
+	weight     int
+}
+
+func convert(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	reader flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	from := strings.ToLower(args[0])
+	to := strings.ToLower(args[1])
+
+	for _, format := range []string{from, to} {
+		if format != formatHex && format != formatPEM && format != formatJWK && format != formatRLP {
+			return nil, fmt.Errorf("key format %s not supported. Valid formats: hex, pem, jwk and rlp", format)
+		}
+	}
+
+	var encoded string
+	if len(args) > 2 {
+		encoded = args[2]
+	}
+
+	if encoded != "" && convertFlags.FromFile != "" {
+		return nil, fmt.Errorf("can not pass both command argument and from file flag")
+	}
+	if encoded == "" && convertFlags.FromFile == "" {
+		return nil, fmt.Errorf("provide argument for encoded key or use from file flag")
+	}
+
+	if convertFlags.FromFile != "" {
+		e, err := reader.ReadFile(convertFlags.FromFile)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(e)
+	}
+	encoded = strings.TrimSpace(encoded)
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(convertFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", convertFlags.SigAlgo)
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(convertFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", convertFlags.HashAlgo)
+	}
+
+	key, err := decodeKey(from, encoded, sigAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s key: %w", from, err)
+	}
+
+	// flags only apply to keys that don't carry the values in their encoding
+	if key.hashAlgo == crypto.UnknownHashAlgorithm {
+		key.hashAlgo = hashAlgo
+		key.weight = convertFlags.Weight
+	}
+
+	result, err := encodeKey(to, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s key: %w", to, err)
+	}
+
+	return &convertResult{
+		format:  to,
+		key:     result,
+		private: key.privateKey != nil && to != formatRLP,
+		sigAlgo: key.publicKey.Algorithm(),
+	}, nil
+}
+
+func decodeKey(format string, encoded string, sigAlgo crypto.SignatureAlgorithm) (*convertedKey, error) {
+	switch format {
+	case formatHex:
+		return decodeHexKey(encoded, sigAlgo)
+	case formatPEM:
+		return decodePEMKey(encoded)
+	case formatJWK:
+		return decodeJWK(encoded)
+	default:
+		accountKey, err := decodeRLP(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		return &convertedKey{
+			publicKey: accountKey.PublicKey,
+			hashAlgo:  accountKey.HashAlgo,
+			weight:    accountKey.Weight,
+		}, nil
+	}
+}
+
+func encodeKey(format string, key *convertedKey) (string, error) {
+	switch format {
+	case formatHex:
+		if key.privateKey != nil {
+			return hex.EncodeToString(key.privateKey.Encode()), nil
+		}
+		return hex.EncodeToString(key.publicKey.Encode()), nil
+	case formatPEM:
+		return encodePEMKey(key)
+	case formatJWK:
+		return encodeJWK(key)
+	default:
+		accountKey := flow.AccountKey{
+			PublicKey: key.publicKey,
+			SigAlgo:   key.publicKey.Algorithm(),
+			HashAlgo:  key.hashAlgo,
+			Weight:    key.weight,
+		}
+		return hex.EncodeToString(accountKey.Encode()), nil
+	}
+}
+
+// decodeHexKey decodes a raw private key scalar or a raw public key point.
+func decodeHexKey(encoded string, sigAlgo crypto.SignatureAlgorithm) (*convertedKey, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(raw) == crypto.MinSeedLength {
+		return newPrivateConvertedKey(sigAlgo, raw)
+	}
+
+	return newPublicConvertedKey(sigAlgo, raw)
+}
+
+func decodePEMKey(encoded string) (*convertedKey, error) {
+	block, rest := pem.Decode([]byte(encoded))
+	if block == nil || len(bytes.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("invalid PEM encoding")
+	}
+
+	switch block.Type {
+	case pemPrivateKey:
+		var key pkcs8PrivateKey
+		if _, err := asn1.Unmarshal(block.Bytes, &key); err != nil {
+			return nil, fmt.Errorf("invalid PKCS#8 private key: %w", err)
+		}
+		if !key.Algorithm.Algorithm.Equal(oidECPublicKey) {
+			return nil, fmt.Errorf("PKCS#8 private key is not an EC key")
+		}
+
+		sigAlgo, err := sigAlgoFromOID(key.Algorithm.NamedCurve)
+		if err != nil {
+			return nil, err
+		}
+
+		return decodeECPrivateKey(key.PrivateKey, sigAlgo)
+	case pemECPrivateKey:
+		return decodeECPrivateKey(block.Bytes, crypto.UnknownSignatureAlgorithm)
+	case pemPublicKey:
+		var key subjectPublicKeyInfo
+		if _, err := asn1.Unmarshal(block.Bytes, &key); err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+		if !key.Algorithm.Algorithm.Equal(oidECPublicKey) {
+			return nil, fmt.Errorf("public key is not an EC key")
+		}
+
+		sigAlgo, err := sigAlgoFromOID(key.Algorithm.NamedCurve)
+		if err != nil {
+			return nil, err
+		}
+
+		point := key.PublicKey.RightAlign()
+		if len(point) == 0 || point[0] != 0x04 {
+			return nil, fmt.Errorf("public key is not an uncompressed EC point")
+		}
+
+		return newPublicConvertedKey(sigAlgo, point[1:])
+	default:
+		return nil, fmt.Errorf("PEM type %s not supported", block.Type)
+	}
+}
+
+// decodeECPrivateKey decodes a SEC1 private key, the curve is taken from the key if not known.
+func decodeECPrivateKey(der []byte, sigAlgo crypto.SignatureAlgorithm) (*convertedKey, error) {
+	var key ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, fmt.Errorf("invalid EC private key: %w", err)
+	}
+
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		var err error
+		sigAlgo, err = sigAlgoFromOID(key.NamedCurve)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newPrivateConvertedKey(sigAlgo, key.PrivateKey)
+}
+
+func encodePEMKey(key *convertedKey) (string, error) {
+	curve := curveOIDFromSigAlgo(key.publicKey.Algorithm())
+	algorithm := algorithmIdentifier{Algorithm: oidECPublicKey, NamedCurve: curve}
+
+	var block *pem.Block
+	if key.privateKey != nil {
+		// the curve is part of the PKCS#8 algorithm so it's omitted from the inner key
+		inner, err := asn1.Marshal(ecPrivateKey{
+			Version:    1,
+			PrivateKey: key.privateKey.Encode(),
+			PublicKey:  uncompressedPoint(key.publicKey),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		der, err := asn1.Marshal(pkcs8PrivateKey{Algorithm: algorithm, PrivateKey: inner})
+		if err != nil {
+			return "", err
+		}
+
+		block = &pem.Block{Type: pemPrivateKey, Bytes: der}
+	} else {
+		der, err := asn1.Marshal(subjectPublicKeyInfo{
+			Algorithm: algorithm,
+			PublicKey: uncompressedPoint(key.publicKey),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		block = &pem.Block{Type: pemPublicKey, Bytes: der}
+	}
+
+	return strings.TrimSpace(string(pem.EncodeToMemory(block))), nil
+}
+
+func decodeJWK(encoded string) (*convertedKey, error) {
+	var key jsonWebKey
+	if err := json.Unmarshal([]byte(encoded), &key); err != nil {
+		return nil, fmt.Errorf("invalid JWK: %w", err)
+	}
+
+	if key.Kty != "EC" {
+		return nil, fmt.Errorf("JWK key type %s not supported", key.Kty)
+	}
+
+	var sigAlgo crypto.SignatureAlgorithm
+	switch key.Crv {
+	case "P-256":
+		sigAlgo = crypto.ECDSA_P256
+	case "secp256k1":
+		sigAlgo = crypto.ECDSA_secp256k1
+	default:
+		return nil, fmt.Errorf("JWK curve %s not supported", key.Crv)
+	}
+
+	if key.D != "" {
+		d, err := base64.RawURLEncoding.DecodeString(key.D)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWK private key: %w", err)
+		}
+
+		return newPrivateConvertedKey(sigAlgo, d)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK x coordinate: %w", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(key.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK y coordinate: %w", err)
+	}
+
+	return newPublicConvertedKey(sigAlgo, append(x, y...))
+}
+
+func encodeJWK(key *convertedKey) (string, error) {
+	point := key.publicKey.Encode()
+	size := len(point) / 2
+
+	jwk := jsonWebKey{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(point[:size]),
+		Y:   base64.RawURLEncoding.EncodeToString(point[size:]),
+	}
+	if key.publicKey.Algorithm() == crypto.ECDSA_secp256k1 {
+		jwk.Crv = "secp256k1"
+	}
+	if key.privateKey != nil {
+		jwk.D = base64.RawURLEncoding.EncodeToString(key.privateKey.Encode())
+	}
+
+	encoded, err := json.Marshal(jwk)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+func newPrivateConvertedKey(sigAlgo crypto.SignatureAlgorithm, raw []byte) (*convertedKey, error) {
+	privateKey, err := crypto.DecodePrivateKey(sigAlgo, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &convertedKey{privateKey: privateKey, publicKey: privateKey.PublicKey()}, nil
+}
+
+func newPublicConvertedKey(sigAlgo crypto.SignatureAlgorithm, raw []byte) (*convertedKey, error) {
+	publicKey, err := crypto.DecodePublicKey(sigAlgo, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &convertedKey{publicKey: publicKey}, nil
+}
+
+func sigAlgoFromOID(oid asn1.ObjectIdentifier) (crypto.SignatureAlgorithm, error) {
+	switch {
+	case oid.Equal(oidP256):
+		return crypto.ECDSA_P256, nil
+	case oid.Equal(oidSecp256k1):
+		return crypto.ECDSA_secp256k1, nil
+	default:
+		return crypto.UnknownSignatureAlgorithm, fmt.Errorf("curve %s not supported", oid)
+	}
+}
+
+func curveOIDFromSigAlgo(sigAlgo crypto.SignatureAlgorithm) asn1.ObjectIdentifier {
+	if sigAlgo == crypto.ECDSA_secp256k1 {
+		return oidSecp256k1
+	}
+
+	return oidP256
+}
+
+func uncompressedPoint(publicKey crypto.PublicKey) asn1.BitString {
+	point := append([]byte{0x04}, publicKey.Encode()...)
+	return asn1.BitString{Bytes: point, BitLength: len(point) * 8}
+}
+
+type convertResult struct {
+	format  string
+	key     string
+	private bool
+	sigAlgo crypto.SignatureAlgorithm
+}
+
+func (r *convertResult) JSON() any {
+	return map[string]any{
+		"format":  r.format,
+		"key":     r.key,
+		"private": r.private,
+		"sigAlgo": r.sigAlgo.String(),
+	}
+}
+
+func (r *convertResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if r.private {
+		_, _ = fmt.Fprintf(writer, "%s Store private key safely and don't share with anyone! \n", output.StopEmoji())
+	}
+	_, _ = fmt.Fprintf(writer, "Signature Algorithm \t %s\n\n", r.sigAlgo)
+	_ = writer.Flush()
+
+	_, _ = fmt.Fprintf(&b, "%s\n", r.key)
+
+	return b.String()
+}
+
+func (r *convertResult) Oneliner() string {
+	return r.key
+}
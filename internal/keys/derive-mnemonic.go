@@ -0,0 +1,193 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/prompt"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDeriveMnemonic struct {
+	Mnemonic   string `default:"" flag:"mnemonic" info:"Mnemonic seed to derive keys from, prompted for if not provided"`
+	Count      int    `default:"10" flag:"count" info:"Number of keys to derive"`
+	Start      int    `default:"0" flag:"start" info:"Index of the first derived key"`
+	BasePath   string `default:"m/44'/539'/0'/0" flag:"base-path" info:"Derivation path the key index is appended to"`
+	KeySigAlgo string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	Account    string `default:"" flag:"account" info:"Address of an account to check the derived keys are registered on"`
+}
+
+var deriveMnemonicFlags = flagsDeriveMnemonic{}
+
+var deriveMnemonicCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "derive-mnemonic",
+		Short:   "Derive public keys for consecutive derivation paths of a mnemonic",
+		Example: "flow keys derive-mnemonic --count 10 --account f8d6e0586b0a20c7",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &deriveMnemonicFlags,
+	Run:   deriveMnemonic,
+}
+
+func deriveMnemonic(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	sigAlgo := crypto.StringToSignatureAlgorithm(deriveMnemonicFlags.KeySigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", deriveMnemonicFlags.KeySigAlgo)
+	}
+
+	if deriveMnemonicFlags.Count < 1 {
+		return nil, fmt.Errorf("count must be at least 1")
+	}
+	if deriveMnemonicFlags.Start < 0 {
+		return nil, fmt.Errorf("start index can not be negative")
+	}
+
+	mnemonic := deriveMnemonicFlags.Mnemonic
+	if mnemonic == "" {
+		mnemonic = prompt.SecretPrompt("Enter mnemonic")
+	}
+
+	basePath := strings.TrimSuffix(deriveMnemonicFlags.BasePath, "/")
+	derived := make([]derivedKey, 0, deriveMnemonicFlags.Count)
+	for i := deriveMnemonicFlags.Start; i < deriveMnemonicFlags.Start+deriveMnemonicFlags.Count; i++ {
+		path := fmt.Sprintf("%s/%d", basePath, i)
+
+		privateKey, err := flow.DerivePrivateKeyFromMnemonic(context.Background(), mnemonic, sigAlgo, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key for path %s: %w", path, err)
+		}
+
+		derived = append(derived, derivedKey{path: path, publicKey: privateKey.PublicKey(), keyIndex: -1})
+	}
+
+	result := &deriveMnemonicResult{keys: derived, sigAlgo: sigAlgo}
+
+	if deriveMnemonicFlags.Account != "" {
+		address := flowsdk.HexToAddress(deriveMnemonicFlags.Account)
+
+		logger.StartProgress(fmt.Sprintf("Loading account %s...", address))
+		defer logger.StopProgress()
+
+		account, err := flow.GetAccount(context.Background(), address)
+		if err != nil {
+			return nil, err
+		}
+
+		result.account = &address
+		for i, key := range result.keys {
+			for _, accountKey := range account.Keys {
+				if !accountKey.Revoked && accountKey.PublicKey.Equals(key.publicKey) {
+					result.keys[i].keyIndex = int(accountKey.Index)
+					break
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+type derivedKey struct {
+	path      string
+	publicKey crypto.PublicKey
+	keyIndex  int // index of the matching key on the checked account, -1 if not registered
+}
+
+type deriveMnemonicResult struct {
+	keys    []derivedKey
+	sigAlgo crypto.SignatureAlgorithm
+	account *flowsdk.Address
+}
+
+func (r *deriveMnemonicResult) JSON() any {
+	keys := make([]map[string]any, 0, len(r.keys))
+	for _, key := range r.keys {
+		result := map[string]any{
+			"derivationPath": key.path,
+			"public":         fmt.Sprintf("%x", key.publicKey.Encode()),
+		}
+
+		if r.account != nil {
+			result["registered"] = key.keyIndex >= 0
+			if key.keyIndex >= 0 {
+				result["keyIndex"] = key.keyIndex
+			}
+		}
+
+		keys = append(keys, result)
+	}
+
+	return keys
+}
+
+func (r *deriveMnemonicResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if r.account != nil {
+		_, _ = fmt.Fprintf(writer, "Derivation Path\tPublic Key\tAccount 0x%s\n", r.account.Hex())
+	} else {
+		_, _ = fmt.Fprintf(writer, "Derivation Path\tPublic Key\n")
+	}
+
+	for _, key := range r.keys {
+		if r.account == nil {
+			_, _ = fmt.Fprintf(writer, "%s\t%x\n", key.path, key.publicKey.Encode())
+			continue
+		}
+
+		registered := "-"
+		if key.keyIndex >= 0 {
+			registered = fmt.Sprintf("%s key index %d", output.OkEmoji(), key.keyIndex)
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%x\t%s\n", key.path, key.publicKey.Encode(), registered)
+	}
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *deriveMnemonicResult) Oneliner() string {
+	keys := make([]string, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, fmt.Sprintf("%s: %x", key.path, key.publicKey.Encode()))
+	}
+
+	return strings.Join(keys, ", ")
+}
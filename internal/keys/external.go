@@ -0,0 +1,124 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
+)
+
+type flagsExternal struct {
+	KeyIndex uint32 `default:"0" flag:"key-index" info:"Key index sent to the signer"`
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hashing algorithm"`
+}
+
+var externalFlags = flagsExternal{}
+
+var externalCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "external <signer command>",
+		Short: "Check an external signer by requesting its public key and verifying a test signature",
+		Long: `Check an external signer by requesting its public key and verifying a test signature.
+
+Accounts in the configuration sign with the external signer by using its command as the resource ID:
+"key": {"type": "external", "resourceID": "/usr/local/bin/vault-signer --key flow"}
+
+The command is split into arguments like a shell does, arguments containing spaces must be quoted
+or escaped with a backslash, e.g. "'/opt/Vault Signer/sign' --key flow". Backslashes in Windows paths
+must be in single quotes. Signing only requires the signer to support the sign action, this command
+also requests the public key.`,
+		Example: "flow keys external '/usr/local/bin/vault-signer --key flow'",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &externalFlags,
+	Run:   checkExternal,
+}
+
+func checkExternal(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	sigAlgo := crypto.StringToSignatureAlgorithm(externalFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", externalFlags.SigAlgo)
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(externalFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", externalFlags.HashAlgo)
+	}
+
+	key, err := signer.NewExternalKey(args[0], externalFlags.KeyIndex, sigAlgo, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress("Checking external signer...")
+	defer logger.StopProgress()
+
+	publicKey, err := key.PublicKey(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	keySigner, err := key.Signer(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	message := make([]byte, 32)
+	if _, err := rand.Read(message); err != nil {
+		return nil, err
+	}
+
+	signature, err := keySigner.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher, err := crypto.NewHasher(hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := publicKey.Verify(signature, message, hasher)
+	if err != nil || !valid {
+		return nil, fmt.Errorf("external signer signature is not valid for public key %x", publicKey.Encode())
+	}
+
+	return &keyResult{
+		publicKey: publicKey,
+		sigAlgo:   sigAlgo,
+		hashAlgo:  hashAlgo,
+	}, nil
+}
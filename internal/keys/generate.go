@@ -24,6 +24,7 @@ import (
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
+	"github.com/tyler-smith/go-bip39"
 
 	"github.com/onflow/flowkit/v2"
 	"github.com/onflow/flowkit/v2/output"
@@ -33,6 +34,8 @@ import (
 
 type flagsGenerate struct {
 	Mnemonic       string `flag:"mnemonic" info:"Mnemonic seed to use"`
+	NewMnemonic    bool   `default:"false" flag:"new-mnemonic" info:"Generate a new mnemonic seed, which is the default when no mnemonic is provided"`
+	Words          int    `default:"12" flag:"words" info:"Number of words in the mnemonic seed generated when no mnemonic is provided: 12 or 24"`
 	DerivationPath string `default:"m/44'/539'/0'/0/0" flag:"derivationPath" info:"Derivation path"`
 	KeySigAlgo     string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
 }
@@ -43,7 +46,7 @@ var generateCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "generate",
 		Short:   "Generate a new key-pair",
-		Example: "flow keys generate",
+		Example: "flow keys generate --words 24",
 	},
 	Flags: &generateFlags,
 	Run:   generate,
@@ -61,10 +64,14 @@ func generate(
 		return nil, fmt.Errorf("invalid signature algorithm: %s", generateFlags.KeySigAlgo)
 	}
 
+	if generateFlags.NewMnemonic && generateFlags.Mnemonic != "" {
+		return nil, fmt.Errorf("can not use both mnemonic and new mnemonic flags")
+	}
+
 	var err error
 	mnemonic := generateFlags.Mnemonic
 	if mnemonic == "" {
-		_, mnemonic, err = flow.GenerateMnemonicKey(context.Background(), sigAlgo, generateFlags.DerivationPath)
+		mnemonic, err = newMnemonic(generateFlags.Words)
 		if err != nil {
 			return nil, err
 		}
@@ -88,3 +95,23 @@ func generate(
 		derivationPath: generateFlags.DerivationPath,
 	}, nil
 }
+
+// newMnemonic generates a BIP39 mnemonic with the number of words.
+func newMnemonic(words int) (string, error) {
+	var entropyBits int
+	switch words {
+	case 12:
+		entropyBits = 128
+	case 24:
+		entropyBits = 256
+	default:
+		return "", fmt.Errorf("invalid number of mnemonic words %d, valid values are 12 and 24", words)
+	}
+
+	entropy, err := bip39.NewEntropy(entropyBits)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate mnemonic: %w", err)
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
@@ -41,7 +41,14 @@ var Cmd = &cobra.Command{
 func init() {
 	generateCommand.AddToParent(Cmd)
 	decodeCommand.AddToParent(Cmd)
+	convertCommand.AddToParent(Cmd)
+	splitCommand.AddToParent(Cmd)
+	combineCommand.AddToParent(Cmd)
 	deriveCommand.AddToParent(Cmd)
+	deriveMnemonicCommand.AddToParent(Cmd)
+	pkcs11Command.AddToParent(Cmd)
+	externalCommand.AddToParent(Cmd)
+	Cmd.AddCommand(keystoreCmd)
 }
 
 type keyResult struct {
@@ -56,7 +63,7 @@ type keyResult struct {
 
 func (k *keyResult) JSON() any {
 	result := make(map[string]any)
-	result["public"] = hex.EncodeToString(k.privateKey.PublicKey().Encode())
+	result["public"] = hex.EncodeToString(k.publicKey.Encode())
 
 	if k.privateKey != nil {
 		result["private"] = hex.EncodeToString(k.privateKey.Encode())
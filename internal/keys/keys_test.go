@@ -19,12 +19,26 @@
 package keys
 
 import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/config"
+	"github.com/onflow/flowkit/v2/tests"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -142,9 +156,360 @@ func Test_DeriveKeys(t *testing.T) {
 func Test_Generate(t *testing.T) {
 	srv, _, rw := util.TestMocks(t)
 
+	t.Run("New mnemonic", func(t *testing.T) {
+		key, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+		require.NoError(t, err)
+		srv.Mock.On("DerivePrivateKeyFromMnemonic", mock.Anything, mock.Anything, crypto.ECDSA_P256, "m/44'/539'/0'/0/0").Return(key, nil)
+
+		for _, words := range []int{12, 24} {
+			generateFlags.Words = words
+			result, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+			require.NoError(t, err)
+			assert.Len(t, strings.Fields(result.(*keyResult).mnemonic), words)
+		}
+
+		generateFlags.Words = 18
+		_, err = generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid number of mnemonic words 18, valid values are 12 and 24")
+
+		generateFlags.Words = 12
+		generateFlags.NewMnemonic = true
+		result, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Len(t, strings.Fields(result.(*keyResult).mnemonic), 12)
+
+		generateFlags.Mnemonic = "test"
+		_, err = generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "can not use both mnemonic and new mnemonic flags")
+
+		generateFlags.NewMnemonic = false
+		result, err = generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "test", result.(*keyResult).mnemonic)
+
+		generateFlags.Mnemonic = ""
+	})
+
 	t.Run("Fail invalid signature algorithm", func(t *testing.T) {
 		generateFlags.KeySigAlgo = "invalid"
 		_, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
 		assert.EqualError(t, err, "invalid signature algorithm: invalid")
 	})
 }
+
+func Test_DeriveMnemonic(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	const mnemonic = "test test test test test test test test test test test junk"
+	keys := make([]crypto.PrivateKey, 3)
+	for i := range keys {
+		seed := make([]byte, crypto.MinSeedLength)
+		seed[0] = byte(i)
+		key, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+		require.NoError(t, err)
+		keys[i] = key
+
+		path := fmt.Sprintf("m/44'/539'/0'/0/%d", i+1)
+		srv.Mock.On("DerivePrivateKeyFromMnemonic", mock.Anything, mnemonic, crypto.ECDSA_P256, path).Return(key, nil)
+	}
+
+	deriveMnemonicFlags.Mnemonic = mnemonic
+	deriveMnemonicFlags.Start = 1
+	deriveMnemonicFlags.Count = 3
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := deriveMnemonic([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		assert.Equal(t, fmt.Sprintf(
+			"m/44'/539'/0'/0/1: %x, m/44'/539'/0'/0/2: %x, m/44'/539'/0'/0/3: %x",
+			keys[0].PublicKey().Encode(),
+			keys[1].PublicKey().Encode(),
+			keys[2].PublicKey().Encode(),
+		), result.Oneliner())
+	})
+
+	t.Run("Success with account check", func(t *testing.T) {
+		account := tests.NewAccountWithAddress("0xf8d6e0586b0a20c7")
+		account.Keys = []*flow.AccountKey{
+			{Index: 0, PublicKey: keys[0].PublicKey(), Revoked: true},
+			{Index: 1, PublicKey: keys[2].PublicKey()},
+		}
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(account, nil)
+		deriveMnemonicFlags.Account = "f8d6e0586b0a20c7"
+
+		result, err := deriveMnemonic([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		keys := result.JSON().([]map[string]any)
+		require.Len(t, keys, 3)
+		assert.Equal(t, false, keys[0]["registered"])
+		assert.Equal(t, false, keys[1]["registered"])
+		assert.Equal(t, true, keys[2]["registered"])
+		assert.Equal(t, 1, keys[2]["keyIndex"])
+
+		deriveMnemonicFlags.Account = ""
+	})
+
+	t.Run("Fail invalid count", func(t *testing.T) {
+		deriveMnemonicFlags.Count = 0
+		_, err := deriveMnemonic([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "count must be at least 1")
+	})
+}
+
+func Test_Keystore(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	t.Setenv(signer.KeystorePassphraseEnv, "correct horse battery staple")
+
+	const privateKey = "cf3178b20a73846dc8bf6255c79be47178b0744dd8244bcff099e449a9700d7f"
+	key, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, privateKey)
+	require.NoError(t, err)
+
+	t.Run("Import, list and export", func(t *testing.T) {
+		_ = rw.WriteFile("alice.pkey", []byte("0x"+privateKey+"\n"), 0600)
+		keystoreImportFlags.FromFile = "alice.pkey"
+		keystoreImportFlags.Keystore = "keystore.json"
+		keystoreListFlags.Keystore = "keystore.json"
+		keystoreExportFlags.Keystore = "keystore.json"
+
+		result, err := keystoreImport([]string{"alice"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "Name: alice, Public Key: "+hex.EncodeToString(key.PublicKey().Encode()), result.Oneliner())
+
+		data, err := rw.ReadFile("keystore.json")
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), privateKey)
+
+		_, err = keystoreImport([]string{"alice"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "key with name alice already exists in the keystore")
+
+		result, err = keystoreList([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "Keys: [alice]", result.Oneliner())
+
+		result, err = keystoreExport([]string{"alice"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, privateKey, result.JSON().(map[string]any)["private"])
+
+		_, err = keystoreExport([]string{"bob"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "key with name bob does not exist in the keystore")
+
+		t.Setenv(signer.KeystorePassphraseEnv, "wrong")
+		_, err = keystoreExport([]string{"alice"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "failed to decrypt key alice, check the passphrase")
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		srv.Mock.On("GenerateKey", mock.Anything, crypto.ECDSA_P256, "").Return(key, nil)
+		keystoreCreateFlags.Keystore = "created.json"
+
+		result, err := keystoreCreate([]string{"bob"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "Name: bob, Public Key: "+hex.EncodeToString(key.PublicKey().Encode()), result.Oneliner())
+
+		ks, err := signer.LoadKeystore(rw, "created.json")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob"}, ks.Names())
+	})
+
+	t.Run("Fail invalid private key", func(t *testing.T) {
+		_ = rw.WriteFile("invalid.pkey", []byte("invalid"), 0600)
+		keystoreImportFlags.FromFile = "invalid.pkey"
+
+		_, err := keystoreImport([]string{"carol"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "invalid private key")
+	})
+}
+
+func Test_External(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Fail missing signer", func(t *testing.T) {
+		_, err := checkExternal([]string{"flow-missing-signer"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "external signer flow-missing-signer not found")
+	})
+
+	t.Run("Fail invalid hash algorithm", func(t *testing.T) {
+		externalFlags.HashAlgo = "invalid"
+		_, err := checkExternal([]string{"signer"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid hash algorithm: invalid")
+		externalFlags.HashAlgo = "SHA3_256"
+	})
+}
+
+func Test_Convert(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	const privateKey = "cf3178b20a73846dc8bf6255c79be47178b0744dd8244bcff099e449a9700d7f"
+
+	run := func(t *testing.T, from, to, key string) *convertResult {
+		result, err := convert([]string{from, to, key}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		return result.(*convertResult)
+	}
+
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		t.Run(fmt.Sprintf("Round trip %s", sigAlgo), func(t *testing.T) {
+			convertFlags.SigAlgo = sigAlgo.String()
+			key, err := crypto.DecodePrivateKeyHex(sigAlgo, privateKey)
+			require.NoError(t, err)
+			publicKey := hex.EncodeToString(key.PublicKey().Encode())
+
+			for _, format := range []string{"pem", "jwk"} {
+				encoded := run(t, "hex", format, privateKey)
+				assert.True(t, encoded.private)
+				assert.Equal(t, sigAlgo, encoded.sigAlgo)
+				assert.Equal(t, privateKey, run(t, format, "hex", encoded.key).key)
+
+				encoded = run(t, "hex", format, publicKey)
+				assert.False(t, encoded.private)
+				assert.Equal(t, publicKey, run(t, format, "hex", encoded.key).key)
+			}
+
+			rlp := run(t, "hex", "rlp", privateKey)
+			assert.False(t, rlp.private)
+			accountKey, err := decodeRLP(rlp.key)
+			require.NoError(t, err)
+			assert.True(t, accountKey.PublicKey.Equals(key.PublicKey()))
+			assert.Equal(t, sigAlgo, accountKey.SigAlgo)
+			assert.Equal(t, crypto.SHA3_256, accountKey.HashAlgo)
+			assert.Equal(t, 1000, accountKey.Weight)
+		})
+	}
+	convertFlags.SigAlgo = "ECDSA_P256"
+
+	t.Run("Standard encodings", func(t *testing.T) {
+		pemPrivate := run(t, "hex", "pem", privateKey).key
+		block, _ := pem.Decode([]byte(pemPrivate))
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		require.NoError(t, err)
+		assert.Equal(t, privateKey, fmt.Sprintf("%064x", parsed.(*ecdsa.PrivateKey).D))
+
+		pemPublic := "-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE1HmzzcntvdsZXLErNRYa3oJrAypk\nvdQGLMh/s7p+ccnPZG/yOZC7RTLKRcRFx+kIzvJ4ssRhU2ADmmZgo2apXw==\n-----END PUBLIC KEY-----"
+		assert.Equal(t, pemPublic, run(t, "pem", "pem", pemPublic).key)
+
+		jwk := run(t, "pem", "jwk", pemPublic).key
+		assert.Equal(t, `{"kty":"EC","crv":"P-256","x":"1HmzzcntvdsZXLErNRYa3oJrAypkvdQGLMh_s7p-cck","y":"z2Rv8jmQu0UyykXERcfpCM7yeLLEYVNgA5pmYKNmqV8"}`, jwk)
+	})
+
+	t.Run("Fail invalid input", func(t *testing.T) {
+		_, err := convert([]string{"hex", "pem", "aaaa"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "failed to decode hex key")
+
+		_, err = convert([]string{"hex", "der", privateKey}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "key format der not supported. Valid formats: hex, pem, jwk and rlp")
+
+		_, err = convert([]string{"jwk", "hex", `{"kty":"RSA"}`}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "failed to decode jwk key: JWK key type RSA not supported")
+	})
+}
+
+func Test_Shamir(t *testing.T) {
+	t.Run("Split and combine", func(t *testing.T) {
+		secret := []byte("flow private key material 123456")
+
+		for _, params := range [][2]int{{2, 2}, {5, 3}, {10, 10}} {
+			shares, err := splitSecret(secret, params[0], params[1])
+			require.NoError(t, err)
+			require.Len(t, shares, params[0])
+
+			// any threshold sized subset reconstructs the secret
+			for start := 0; start+params[1] <= params[0]; start++ {
+				indexes := make([]byte, 0)
+				data := make([][]byte, 0)
+				for i := start; i < start+params[1]; i++ {
+					indexes = append(indexes, byte(i+1))
+					data = append(data, shares[i])
+				}
+				assert.Equal(t, secret, combineSecret(indexes, data))
+			}
+
+			// less than threshold shares don't
+			assert.NotEqual(t, secret, combineSecret([]byte{1}, shares[:1]))
+		}
+	})
+
+	t.Run("Fail invalid parameters", func(t *testing.T) {
+		_, err := splitSecret([]byte{1}, 5, 1)
+		assert.EqualError(t, err, "threshold must be at least 2")
+
+		_, err = splitSecret([]byte{1}, 2, 3)
+		assert.EqualError(t, err, "number of shares must be at least the threshold")
+
+		_, err = splitSecret([]byte{1}, 256, 3)
+		assert.EqualError(t, err, "number of shares can be at most 255")
+	})
+}
+
+func Test_SplitCombine(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	const privateKey = "cf3178b20a73846dc8bf6255c79be47178b0744dd8244bcff099e449a9700d7f"
+	key, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, privateKey)
+	require.NoError(t, err)
+	publicKey := hex.EncodeToString(key.PublicKey().Encode())
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := split([]string{privateKey}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		shares := strings.Fields(result.Oneliner())
+		require.Len(t, shares, 5)
+
+		combineFlags.PublicKey = publicKey
+		combined, err := combine([]string{shares[4], shares[0], shares[2]}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, privateKey, combined.JSON().(map[string]any)["private"])
+		assert.Equal(t, crypto.SHA3_256, combined.(*keyResult).hashAlgo)
+
+		_, err = combine(shares[:2], command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "3 shares are required to reconstruct the key, got 2")
+
+		_, err = combine([]string{shares[0], shares[0], shares[1]}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "share with index 1 is provided more than once")
+
+		corrupted := strings.Replace(shares[1], ":ECDSA_P256:", ":ECDSA_P256:0", 1)
+		_, err = combine([]string{shares[0], corrupted, shares[2]}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "share 2: invalid share checksum, the share is corrupted or mistyped")
+
+		other, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+		require.NoError(t, err)
+		combineFlags.PublicKey = hex.EncodeToString(other.PublicKey().Encode())
+		_, err = combine(shares[:3], command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "reconstructed private key doesn't match the expected public key")
+	})
+
+	t.Run("Success with account", func(t *testing.T) {
+		accountKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, make([]byte, crypto.MinSeedLength))
+		require.NoError(t, err)
+		state.Accounts().AddOrUpdate(&accounts.Account{
+			Name:    "backup",
+			Address: flow.HexToAddress("0x01"),
+			Key:     accounts.NewHexKeyFromPrivateKey(0, crypto.SHA2_256, accountKey),
+		})
+		require.NoError(t, state.SaveDefault())
+
+		splitFlags.Account = "backup"
+		result, err := split([]string{}, command.GlobalFlags{ConfigPaths: config.DefaultPaths()}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		splitFlags.Account = ""
+
+		shares := strings.Fields(result.Oneliner())
+		_ = rw.WriteFile("shares.txt", []byte(strings.Join(shares[1:4], "\n")+"\n"), 0600)
+
+		combineFlags.FromFile = "shares.txt"
+		combineFlags.PublicKey = hex.EncodeToString(accountKey.PublicKey().Encode())
+		combined, err := combine([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(accountKey.Encode()), combined.JSON().(map[string]any)["private"])
+		assert.Equal(t, crypto.SHA2_256, combined.(*keyResult).hashAlgo)
+		assert.Contains(t, combined.String(), "SHA2_256")
+		combineFlags.FromFile = ""
+	})
+
+	t.Run("Fail missing key", func(t *testing.T) {
+		_, err := split([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "provide either a private key argument or an account name with the account flag")
+	})
+}
@@ -0,0 +1,162 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsKeystoreCreate struct {
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hashing algorithm"`
+	Keystore string `default:"" flag:"keystore" info:"Path to the keystore file, defaults to the Flow CLI settings directory"`
+}
+
+var keystoreCreateFlags = flagsKeystoreCreate{}
+
+var keystoreCreateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "create <name>",
+		Short:   "Generate a new key and store it encrypted in the keystore",
+		Example: "flow keys keystore create alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &keystoreCreateFlags,
+	Run:   keystoreCreate,
+}
+
+func keystoreCreate(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	name := args[0]
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(keystoreCreateFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", keystoreCreateFlags.SigAlgo)
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(keystoreCreateFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", keystoreCreateFlags.HashAlgo)
+	}
+
+	privateKey, err := flow.GenerateKey(context.Background(), sigAlgo, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	return storeKeystoreKey(rw, logger, keystoreCreateFlags.Keystore, name, privateKey, hashAlgo)
+}
+
+// storeKeystoreKey encrypts the private key with the keystore passphrase and saves it under the name.
+func storeKeystoreKey(
+	rw flowkit.ReaderWriter,
+	logger output.Logger,
+	path string,
+	name string,
+	privateKey crypto.PrivateKey,
+	hashAlgo crypto.HashAlgorithm,
+) (command.Result, error) {
+	path = signer.KeystorePath(path)
+
+	ks, err := signer.LoadKeystore(rw, path)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := ks.Keys[name]; exists {
+		return nil, fmt.Errorf("key with name %s already exists in the keystore", name)
+	}
+
+	passphrase, err := signer.KeystorePassphrase(true)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress("Encrypting key...")
+	defer logger.StopProgress()
+
+	if err := ks.Add(name, privateKey, hashAlgo, passphrase); err != nil {
+		return nil, err
+	}
+	if err := ks.Save(rw, path); err != nil {
+		return nil, err
+	}
+
+	logger.StopProgress()
+
+	return &keystoreKeyResult{
+		name:      name,
+		path:      path,
+		publicKey: privateKey.PublicKey(),
+		sigAlgo:   privateKey.Algorithm(),
+		hashAlgo:  hashAlgo,
+	}, nil
+}
+
+type keystoreKeyResult struct {
+	name      string
+	path      string
+	publicKey crypto.PublicKey
+	sigAlgo   crypto.SignatureAlgorithm
+	hashAlgo  crypto.HashAlgorithm
+}
+
+func (r *keystoreKeyResult) JSON() any {
+	return map[string]any{
+		"name":     r.name,
+		"keystore": r.path,
+		"public":   fmt.Sprintf("%x", r.publicKey.Encode()),
+		"sigAlgo":  r.sigAlgo.String(),
+		"hashAlgo": r.hashAlgo.String(),
+	}
+}
+
+func (r *keystoreKeyResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Key '%s' stored encrypted in %s\n\n", output.SuccessEmoji(), r.name, r.path)
+	_, _ = fmt.Fprintf(writer, "Public Key \t %x \n", r.publicKey.Encode())
+	_, _ = fmt.Fprintf(writer, "Signature Algorithm \t %s\n", r.sigAlgo)
+	_, _ = fmt.Fprintf(writer, "Hash Algorithm \t %s\n", r.hashAlgo)
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *keystoreKeyResult) Oneliner() string {
+	return fmt.Sprintf("Name: %s, Public Key: %x", r.name, r.publicKey.Encode())
+}
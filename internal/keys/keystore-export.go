@@ -0,0 +1,86 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
+)
+
+type flagsKeystoreExport struct {
+	Keystore string `default:"" flag:"keystore" info:"Path to the keystore file, defaults to the Flow CLI settings directory"`
+}
+
+var keystoreExportFlags = flagsKeystoreExport{}
+
+var keystoreExportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "export <name>",
+		Short:   "Decrypt a key from the keystore and output the private key",
+		Example: "flow keys keystore export alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &keystoreExportFlags,
+	Run:   keystoreExport,
+}
+
+func keystoreExport(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	name := args[0]
+
+	ks, err := signer.LoadKeystore(rw, signer.KeystorePath(keystoreExportFlags.Keystore))
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := ks.Keys[name]; !exists {
+		return nil, fmt.Errorf("key with name %s does not exist in the keystore", name)
+	}
+
+	passphrase, err := signer.KeystorePassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress("Decrypting key...")
+	defer logger.StopProgress()
+
+	privateKey, hashAlgo, err := ks.Decrypt(name, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyResult{
+		privateKey: privateKey,
+		publicKey:  privateKey.PublicKey(),
+		sigAlgo:    privateKey.Algorithm(),
+		hashAlgo:   hashAlgo,
+	}, nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/prompt"
+)
+
+type flagsKeystoreImport struct {
+	FromFile string `default:"" flag:"from-file" info:"Load the hex encoded private key from file instead of prompting for it"`
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hashing algorithm"`
+	Keystore string `default:"" flag:"keystore" info:"Path to the keystore file, defaults to the Flow CLI settings directory"`
+}
+
+var keystoreImportFlags = flagsKeystoreImport{}
+
+var keystoreImportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "import <name>",
+		Short:   "Import an existing private key into the keystore",
+		Example: "flow keys keystore import alice --from-file ./alice.pkey",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &keystoreImportFlags,
+	Run:   keystoreImport,
+}
+
+func keystoreImport(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	name := args[0]
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(keystoreImportFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", keystoreImportFlags.SigAlgo)
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(keystoreImportFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", keystoreImportFlags.HashAlgo)
+	}
+
+	var encoded string
+	if keystoreImportFlags.FromFile != "" {
+		data, err := rw.ReadFile(keystoreImportFlags.FromFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key from %s: %w", keystoreImportFlags.FromFile, err)
+		}
+		encoded = string(data)
+	} else {
+		encoded = prompt.SecretPrompt("Enter private key")
+	}
+
+	privateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(strings.TrimSpace(encoded), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return storeKeystoreKey(rw, logger, keystoreImportFlags.Keystore, name, privateKey, hashAlgo)
+}
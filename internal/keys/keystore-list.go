@@ -0,0 +1,110 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsKeystoreList struct {
+	Keystore string `default:"" flag:"keystore" info:"Path to the keystore file, defaults to the Flow CLI settings directory"`
+}
+
+var keystoreListFlags = flagsKeystoreList{}
+
+var keystoreListCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "list",
+		Short:   "List keys stored in the keystore",
+		Example: "flow keys keystore list",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &keystoreListFlags,
+	Run:   keystoreList,
+}
+
+func keystoreList(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	rw flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	path := signer.KeystorePath(keystoreListFlags.Keystore)
+
+	ks, err := signer.LoadKeystore(rw, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keystoreListResult{path: path, keystore: ks}, nil
+}
+
+type keystoreListResult struct {
+	path     string
+	keystore *signer.Keystore
+}
+
+func (r *keystoreListResult) JSON() any {
+	keys := make([]map[string]string, 0, len(r.keystore.Keys))
+	for _, name := range r.keystore.Names() {
+		entry := r.keystore.Keys[name]
+		keys = append(keys, map[string]string{
+			"name":     name,
+			"public":   entry.PublicKey,
+			"sigAlgo":  entry.SigAlgo,
+			"hashAlgo": entry.HashAlgo,
+		})
+	}
+
+	return keys
+}
+
+func (r *keystoreListResult) String() string {
+	if len(r.keystore.Keys) == 0 {
+		return fmt.Sprintf("No keys found in keystore %s\n", r.path)
+	}
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Name\tSignature Algorithm\tHash Algorithm\tPublic Key\n")
+	for _, name := range r.keystore.Names() {
+		entry := r.keystore.Keys[name]
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", name, entry.SigAlgo, entry.HashAlgo, entry.PublicKey)
+	}
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *keystoreListResult) Oneliner() string {
+	return fmt.Sprintf("Keys: %v", r.keystore.Names())
+}
@@ -0,0 +1,45 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/signer"
+)
+
+var keystoreCmd = &cobra.Command{
+	Use:   "keystore",
+	Short: "Manage private keys in an encrypted local keystore",
+	Long: `Manage private keys in an encrypted local keystore.
+
+Accounts in the configuration use keystore keys by name, the location of the keystore is optional:
+"key": {"type": "keystore", "resourceID": "alice", "location": "keystore.json"}
+
+The keystore passphrase is read from the ` + signer.KeystorePassphraseEnv + ` environment variable or prompted for.`,
+	Example:          "flow keys keystore list",
+	TraverseChildren: true,
+}
+
+func init() {
+	keystoreCreateCommand.AddToParent(keystoreCmd)
+	keystoreImportCommand.AddToParent(keystoreCmd)
+	keystoreListCommand.AddToParent(keystoreCmd)
+	keystoreExportCommand.AddToParent(keystoreCmd)
+}
@@ -0,0 +1,102 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
+)
+
+type flagsPKCS11 struct {
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hashing algorithm"`
+}
+
+var pkcs11Flags = flagsPKCS11{}
+
+var pkcs11Command = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "pkcs11 <pkcs11 uri>",
+		Short: "Show the public key of a key stored in a PKCS#11 token",
+		Long: fmt.Sprintf(
+			"Show the public key of a key stored in a PKCS#11 token. The key is referenced with a PKCS#11 URI "+
+				"containing the slot, the key label and the module path. The user PIN is read from the pin-value "+
+				"URI attribute or the %s environment variable.\n\n"+
+				"Accounts in the configuration sign with the token key by using the URI as the resource ID, "+
+				"the pin-value attribute is not stored in the configuration:\n"+
+				`"key": {"type": "pkcs11", "resourceID": "pkcs11:slot-id=0;object=flow-key?module-path=/usr/lib/softhsm/libsofthsm2.so"}`,
+			signer.PinEnv,
+		),
+		Example: "flow keys pkcs11 'pkcs11:slot-id=0;object=flow-key?module-path=/usr/lib/softhsm/libsofthsm2.so'",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &pkcs11Flags,
+	Run:   showPKCS11,
+}
+
+func showPKCS11(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	sigAlgo := crypto.StringToSignatureAlgorithm(pkcs11Flags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", pkcs11Flags.SigAlgo)
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(pkcs11Flags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", pkcs11Flags.HashAlgo)
+	}
+
+	key, err := signer.NewPKCS11Key(args[0], 0, sigAlgo, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading key %s from slot %d...", key.Label(), key.Slot()))
+	defer logger.StopProgress()
+
+	keySigner, err := key.Signer(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	publicKey := keySigner.PublicKey()
+
+	if err := key.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close PKCS#11 session: %w", err)
+	}
+
+	return &keyResult{
+		publicKey: publicKey,
+		sigAlgo:   sigAlgo,
+		hashAlgo:  hashAlgo,
+	}, nil
+}
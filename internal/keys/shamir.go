@@ -0,0 +1,219 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// Shamir's secret sharing over GF(2^8), every byte of the secret is split using
+// a random polynomial of degree threshold-1 evaluated at the share index.
+
+const (
+	sharePrefix  = "flow-share"
+	shareVersion = "v1"
+	maxShares    = 255
+)
+
+var gfExp, gfLog = gfTables()
+
+// gfTables computes the exponent and logarithm tables of GF(2^8) with the AES polynomial and generator 3.
+func gfTables() ([510]byte, [256]byte) {
+	var exp [510]byte
+	var log [256]byte
+
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		exp[i] = x
+		exp[i+255] = x
+		log[x] = byte(i)
+
+		// multiply by the generator 3 = x * 2 + x
+		doubled := x << 1
+		if x&0x80 != 0 {
+			doubled ^= 0x1b
+		}
+		x ^= doubled
+	}
+
+	return exp, log
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+// keyShare is a single share of a split private key.
+type keyShare struct {
+	threshold int
+	index     byte
+	sigAlgo   crypto.SignatureAlgorithm
+	hashAlgo  crypto.HashAlgorithm
+	data      []byte
+}
+
+// splitSecret splits the secret into shares where any threshold of them reconstruct it.
+func splitSecret(secret []byte, shares int, threshold int) ([][]byte, error) {
+	if threshold < 2 {
+		return nil, fmt.Errorf("threshold must be at least 2")
+	}
+	if shares < threshold {
+		return nil, fmt.Errorf("number of shares must be at least the threshold")
+	}
+	if shares > maxShares {
+		return nil, fmt.Errorf("number of shares can be at most %d", maxShares)
+	}
+
+	result := make([][]byte, shares)
+	for i := range result {
+		result[i] = make([]byte, len(secret))
+	}
+
+	coefficients := make([]byte, threshold)
+	for b, value := range secret {
+		coefficients[0] = value
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, err
+		}
+
+		for i := range result {
+			// evaluate the polynomial at x = i+1 using Horner's method
+			x := byte(i + 1)
+			y := byte(0)
+			for c := threshold - 1; c >= 0; c-- {
+				y = gfMul(y, x) ^ coefficients[c]
+			}
+			result[i][b] = y
+		}
+	}
+
+	return result, nil
+}
+
+// combineSecret reconstructs the secret with Lagrange interpolation at x = 0.
+func combineSecret(indexes []byte, shares [][]byte) []byte {
+	secret := make([]byte, len(shares[0]))
+
+	for i, xi := range indexes {
+		// basis polynomial of share i evaluated at 0
+		basis := byte(1)
+		for j, xj := range indexes {
+			if i != j {
+				basis = gfMul(basis, gfDiv(xj, xi^xj))
+			}
+		}
+
+		for b := range secret {
+			secret[b] ^= gfMul(shares[i][b], basis)
+		}
+	}
+
+	return secret
+}
+
+// String encodes the share as flow-share:v1:<threshold>:<index>:<sig algo>:<hash algo>:<data>:<checksum>.
+func (s *keyShare) String() string {
+	body := fmt.Sprintf(
+		"%s:%s:%d:%d:%s:%s:%s",
+		sharePrefix,
+		shareVersion,
+		s.threshold,
+		s.index,
+		s.sigAlgo,
+		s.hashAlgo,
+		hex.EncodeToString(s.data),
+	)
+
+	return fmt.Sprintf("%s:%s", body, shareChecksum(body))
+}
+
+// shareChecksum is the first 4 bytes of the SHA-256 hash of the share body.
+func shareChecksum(body string) string {
+	hash := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(hash[:4])
+}
+
+func parseKeyShare(encoded string) (*keyShare, error) {
+	encoded = strings.TrimSpace(encoded)
+
+	parts := strings.Split(encoded, ":")
+	if len(parts) != 8 || parts[0] != sharePrefix {
+		return nil, fmt.Errorf("invalid share format")
+	}
+	if parts[1] != shareVersion {
+		return nil, fmt.Errorf("unsupported share version %s", parts[1])
+	}
+
+	body := strings.Join(parts[:7], ":")
+	if shareChecksum(body) != strings.ToLower(parts[7]) {
+		return nil, fmt.Errorf("invalid share checksum, the share is corrupted or mistyped")
+	}
+
+	threshold, err := strconv.Atoi(parts[2])
+	if err != nil || threshold < 2 {
+		return nil, fmt.Errorf("invalid share threshold %s", parts[2])
+	}
+
+	index, err := strconv.ParseUint(parts[3], 10, 8)
+	if err != nil || index == 0 {
+		return nil, fmt.Errorf("invalid share index %s", parts[3])
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(parts[4])
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid share signature algorithm %s", parts[4])
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(parts[5])
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid share hash algorithm %s", parts[5])
+	}
+
+	data, err := hex.DecodeString(parts[6])
+	if err != nil {
+		return nil, fmt.Errorf("invalid share data: %w", err)
+	}
+
+	return &keyShare{
+		threshold: threshold,
+		index:     byte(index),
+		sigAlgo:   sigAlgo,
+		hashAlgo:  hashAlgo,
+		data:      data,
+	}, nil
+}
@@ -0,0 +1,177 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/config"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSplit struct {
+	Shares    int    `default:"5" flag:"shares" info:"Number of shares to split the key into"`
+	Threshold int    `default:"3" flag:"threshold" info:"Number of shares needed to reconstruct the key"`
+	Account   string `default:"" flag:"account" info:"Name of the account in the configuration whose key to split"`
+	SigAlgo   string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the hex encoded private key"`
+	HashAlgo  string `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm of the hex encoded private key"`
+}
+
+var splitFlags = flagsSplit{}
+
+var splitCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "split [<hex private key> | --account <name>]",
+		Short:   "Split a private key into Shamir secret shares",
+		Example: "flow keys split --account emulator-account --shares 5 --threshold 3",
+		Args:    cobra.MaximumNArgs(1),
+	},
+	Flags: &splitFlags,
+	Run:   split,
+}
+
+func split(
+	args []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	if (len(args) == 0) == (splitFlags.Account == "") {
+		return nil, fmt.Errorf("provide either a private key argument or an account name with the account flag")
+	}
+
+	var privateKey crypto.PrivateKey
+	var hashAlgo crypto.HashAlgorithm
+	if splitFlags.Account != "" {
+		state, err := signer.Load(globalFlags.ConfigPaths, readerWriter)
+		if errors.Is(err, config.ErrDoesNotExist) {
+			return nil, fmt.Errorf("splitting an account key requires a configuration, run 'flow init' to create one")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		account, err := state.Accounts().ByName(splitFlags.Account)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := account.Key.PrivateKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get private key of account %s: %w", account.Name, err)
+		}
+		privateKey = *key
+		hashAlgo = account.Key.HashAlgo()
+	} else {
+		sigAlgo := crypto.StringToSignatureAlgorithm(splitFlags.SigAlgo)
+		if sigAlgo == crypto.UnknownSignatureAlgorithm {
+			return nil, fmt.Errorf("invalid signature algorithm: %s", splitFlags.SigAlgo)
+		}
+
+		hashAlgo = crypto.StringToHashAlgorithm(splitFlags.HashAlgo)
+		if hashAlgo == crypto.UnknownHashAlgorithm {
+			return nil, fmt.Errorf("invalid hash algorithm: %s", splitFlags.HashAlgo)
+		}
+
+		var err error
+		privateKey, err = crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(args[0], "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode private key: %w", err)
+		}
+	}
+
+	data, err := splitSecret(privateKey.Encode(), splitFlags.Shares, splitFlags.Threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*keyShare, len(data))
+	for i, d := range data {
+		shares[i] = &keyShare{
+			threshold: splitFlags.Threshold,
+			index:     byte(i + 1),
+			sigAlgo:   privateKey.Algorithm(),
+			hashAlgo:  hashAlgo,
+			data:      d,
+		}
+	}
+
+	return &splitResult{shares: shares, publicKey: privateKey.PublicKey()}, nil
+}
+
+type splitResult struct {
+	shares    []*keyShare
+	publicKey crypto.PublicKey
+}
+
+func (r *splitResult) JSON() any {
+	shares := make([]string, len(r.shares))
+	for i, share := range r.shares {
+		shares[i] = share.String()
+	}
+
+	return map[string]any{
+		"shares":    shares,
+		"threshold": r.shares[0].threshold,
+		"public":    fmt.Sprintf("%x", r.publicKey.Encode()),
+	}
+}
+
+func (r *splitResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(
+		writer,
+		"%s Store each share separately, any %d of the %d shares reconstruct the private key! \n",
+		output.StopEmoji(),
+		r.shares[0].threshold,
+		len(r.shares),
+	)
+	_, _ = fmt.Fprintf(writer, "Public Key \t %x \n\n", r.publicKey.Encode())
+
+	for _, share := range r.shares {
+		_, _ = fmt.Fprintf(writer, "Share %d \t %s\n", share.index, share)
+	}
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *splitResult) Oneliner() string {
+	shares := make([]string, len(r.shares))
+	for i, share := range r.shares {
+		shares[i] = share.String()
+	}
+
+	return strings.Join(shares, " ")
+}
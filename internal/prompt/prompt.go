@@ -33,7 +33,6 @@ import (
 	"github.com/manifoldco/promptui"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
-	"github.com/sergi/go-diff/diffmatchpatch"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
@@ -165,6 +164,26 @@ func NamePrompt() string {
 	return name
 }
 
+func SecretPrompt(label string) string {
+	secretPrompt := promptui.Prompt{
+		Label: label,
+		Mask:  '*',
+		Validate: func(s string) error {
+			if len(s) < 1 {
+				return fmt.Errorf("value can not be empty")
+			}
+			return nil
+		},
+	}
+
+	secret, err := secretPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return secret
+}
+
 func AccountNamePrompt(accountNames []string) string {
 	namePrompt := promptui.Prompt{
 		Label: "Enter an account name",
@@ -278,10 +297,7 @@ func addAnotherContractToDeploymentPrompt() bool {
 // returns true if the user wishes to continue with the deployment and false otherwise
 func ShowContractDiffPrompt(logger output.Logger) func([]byte, []byte) bool {
 	return func(newContract []byte, existingContract []byte) bool {
-		dmp := diffmatchpatch.New()
-		diffs := dmp.DiffMain(string(newContract), string(existingContract), false)
-		diffString := dmp.DiffPrettyText(diffs)
-		logger.Info(diffString)
+		logger.Info(util.PrettyDiff(string(newContract), string(existingContract)))
 
 		deployPrompt := promptui.Prompt{
 			Label:     "Do you wish to deploy this contract?",
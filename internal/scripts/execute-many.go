@@ -0,0 +1,305 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/arguments"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsExecuteMany struct {
+	Inputs        string `default:"" flag:"inputs" info:"File with one input per line, lines with comma separated values provide multiple columns"`
+	ArgsJSON      string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format, templated for each input with JSON escaped values"`
+	Concurrency   int    `default:"10" flag:"concurrency" info:"Maximum number of scripts executed at the same time"`
+	ResultsFormat string `default:"jsonl" flag:"results-format" info:"Format of the results: jsonl or csv"`
+}
+
+var executeManyFlags = flagsExecuteMany{}
+
+var executeManyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "execute-many <filename> --inputs <inputs file> [<argument template> ...]",
+		Short: "Execute a script for each input in a file",
+		Long: `Execute a script for each input in a file.
+
+Arguments are templates where {{input}} is replaced by the whole input line and {{1}}, {{2}}, ... by its comma separated columns.
+Without arguments each column of the input is used as an argument. Failures are recorded with the input and don't stop the execution.`,
+		Example: `# execute the script with each address as the argument and save the results as CSV
+flow scripts execute-many get_balance.cdc --inputs addresses.txt --results-format csv --save balances.csv
+
+# use the input in templated arguments
+flow scripts execute-many get_nft.cdc "{{1}}" "/public/{{2}}" --inputs nfts.csv
+
+flow scripts execute-many get_balance.cdc --inputs addresses.txt --args-json '[{"type":"Address","value":"{{input}}"}]'`,
+		Args: cobra.MinimumNArgs(1),
+	},
+	Flags: &executeManyFlags,
+	Run:   executeMany,
+}
+
+const (
+	resultsFormatJSONL = "jsonl"
+	resultsFormatCSV   = "csv"
+)
+
+func executeMany(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if executeManyFlags.Inputs == "" {
+		return nil, fmt.Errorf("inputs file is required, use --inputs flag")
+	}
+	if executeManyFlags.Concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1")
+	}
+
+	format := strings.ToLower(executeManyFlags.ResultsFormat)
+	if format != resultsFormatJSONL && format != resultsFormatCSV {
+		return nil, fmt.Errorf("unsupported results format %s, use jsonl or csv", executeManyFlags.ResultsFormat)
+	}
+
+	filename := args[0]
+	code, err := readerWriter.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading script file: %w", err)
+	}
+
+	inputFile, err := readerWriter.ReadFile(executeManyFlags.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("error loading inputs file: %w", err)
+	}
+
+	inputs, err := parseInputs(inputFile)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Executing script for %d inputs...", len(inputs)))
+	defer logger.StopProgress()
+
+	results := make([]inputResult, len(inputs))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(executeManyFlags.Concurrency, len(inputs)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				value, err := executeForInput(flow, code, filename, args[1:], executeManyFlags.ArgsJSON, inputs[index])
+				results[index] = inputResult{input: inputs[index], value: value, err: err}
+			}
+		}()
+	}
+
+	for index := range inputs {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	return &executeManyResult{results: results, format: format}, nil
+}
+
+// scriptInput is a line of the inputs file split into its columns.
+type scriptInput struct {
+	line    string
+	columns []string
+}
+
+// parseInputs reads the non-empty lines of the inputs file, lines starting with # are ignored.
+func parseInputs(file []byte) ([]scriptInput, error) {
+	inputs := make([]scriptInput, 0)
+	for _, line := range strings.Split(string(file), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		reader := csv.NewReader(strings.NewReader(line))
+		reader.TrimLeadingSpace = true
+		columns, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("invalid input %s: %w", line, err)
+		}
+
+		inputs = append(inputs, scriptInput{line: line, columns: columns})
+	}
+
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("inputs file doesn't contain any inputs")
+	}
+
+	return inputs, nil
+}
+
+// render replaces {{input}} with the whole input and {{n}} with the n-th column.
+func (i scriptInput) render(template string) string {
+	return i.replace(template, func(value string) string { return value })
+}
+
+// renderJSON replaces the placeholders like render, escaping the values so they can be used inside JSON strings.
+func (i scriptInput) renderJSON(template string) string {
+	return i.replace(template, func(value string) string {
+		encoded, _ := json.Marshal(value)
+		return string(encoded[1 : len(encoded)-1])
+	})
+}
+
+func (i scriptInput) replace(template string, escape func(string) string) string {
+	replacements := []string{"{{input}}", escape(i.line)}
+	for index, column := range i.columns {
+		replacements = append(replacements, fmt.Sprintf("{{%d}}", index+1), escape(column))
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+func executeForInput(
+	flow flowkit.Services,
+	code []byte,
+	location string,
+	argTemplates []string,
+	argsJSONTemplate string,
+	input scriptInput,
+) (cadence.Value, error) {
+	var cadenceArgs []cadence.Value
+	var err error
+	if argsJSONTemplate != "" {
+		cadenceArgs, err = arguments.ParseJSON(input.renderJSON(argsJSONTemplate))
+	} else {
+		scriptArgs := input.columns
+		if len(argTemplates) > 0 {
+			scriptArgs = make([]string, 0, len(argTemplates))
+			for _, template := range argTemplates {
+				scriptArgs = append(scriptArgs, input.render(template))
+			}
+		}
+		cadenceArgs, err = arguments.ParseWithoutType(scriptArgs, code, location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing script arguments: %w", err)
+	}
+
+	return flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code:     code,
+			Args:     cadenceArgs,
+			Location: location,
+		},
+		flowkit.LatestScriptQuery,
+	)
+}
+
+type inputResult struct {
+	input scriptInput
+	value cadence.Value
+	err   error
+}
+
+type executeManyResult struct {
+	results []inputResult
+	format  string
+}
+
+func (r *executeManyResult) JSON() any {
+	result := make([]any, 0, len(r.results))
+	for _, item := range r.results {
+		result = append(result, item.record())
+	}
+	return result
+}
+
+// record returns the result of the input as a JSON line.
+func (i inputResult) record() map[string]any {
+	record := map[string]any{"input": i.input.line}
+	if i.err != nil {
+		record["error"] = i.err.Error()
+	} else {
+		record["value"] = json.RawMessage(jsoncdc.MustEncode(i.value))
+	}
+	return record
+}
+
+func (r *executeManyResult) String() string {
+	var b bytes.Buffer
+
+	if r.format == resultsFormatCSV {
+		writer := csv.NewWriter(&b)
+		_ = writer.Write([]string{"input", "value", "error"})
+		for _, item := range r.results {
+			if item.err != nil {
+				_ = writer.Write([]string{item.input.line, "", item.err.Error()})
+			} else {
+				_ = writer.Write([]string{item.input.line, item.value.String(), ""})
+			}
+		}
+		writer.Flush()
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+
+	for i, item := range r.results {
+		line, _ := json.Marshal(item.record())
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.Write(line)
+	}
+
+	return b.String()
+}
+
+func (r *executeManyResult) Oneliner() string {
+	failed := 0
+	for _, item := range r.results {
+		if item.err != nil {
+			failed++
+		}
+	}
+
+	return fmt.Sprintf("executed: %d, failed: %d", len(r.results), failed)
+}
+
+// ExitCode is non-zero when the script failed for any of the inputs.
+func (r *executeManyResult) ExitCode() int {
+	for _, item := range r.results {
+		if item.err != nil {
+			return 1
+		}
+	}
+	return 0
+}
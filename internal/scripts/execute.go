@@ -20,33 +20,53 @@ package scripts
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
 
 	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flowkit/v2"
 	"github.com/onflow/flowkit/v2/arguments"
+	"github.com/onflow/flowkit/v2/config"
 	"github.com/onflow/flowkit/v2/output"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/signer"
 )
 
 type Flags struct {
-	ArgsJSON    string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	BlockID     string `default:"" flag:"block-id" info:"block ID to execute the script at"`
-	BlockHeight uint64 `default:"" flag:"block-height" info:"block height to execute the script at"`
+	ArgsJSON    string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	BlockID     string   `default:"" flag:"block-id" info:"block ID to execute the script at"`
+	BlockHeight uint64   `default:"" flag:"block-height" info:"block height to execute the script at"`
+	Expect      string   `default:"" flag:"expect" info:"Expected result in JSON-Cadence format, exits with a non-zero code if not matched"`
+	Heights     string   `default:"" flag:"heights" info:"range of block heights to execute the script at, in the format start:end[:step]"`
+	AtHeights   []string `default:"" flag:"at-heights" info:"comma separated list of block heights to execute the script at"`
+	ChangesOnly bool     `default:"false" flag:"changes-only" info:"only output the heights where the result changed from the previous height"`
+	Watch       bool     `default:"false" flag:"watch" info:"re-execute the script on new sealed blocks or when the script or its imports change, printing only changed results"`
 }
 
 var flags = Flags{}
 
 var executeCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "execute <filename> [<argument> <argument> ...]",
-		Short:   "Execute a script",
-		Example: `flow scripts execute script.cdc "Meow" "Woof"`,
-		Args:    cobra.MinimumNArgs(1),
+		Use:   "execute <filename> [<argument> <argument> ...]",
+		Short: "Execute a script",
+		Example: `flow scripts execute script.cdc "Meow" "Woof"
+
+# exit with a non-zero code if the script doesn't return the expected value
+flow scripts execute script.cdc --expect '{"type":"Int","value":"42"}'
+
+# execute the script at every 100th block between heights 1000 and 2000 and show only the changes
+flow scripts execute script.cdc --heights 1000:2000:100 --changes-only
+
+# re-execute the script on every new sealed block or file change
+flow scripts execute script.cdc --watch`,
+		Args: cobra.MinimumNArgs(1),
 	},
 	Flags: &flags,
 	Run:   execute,
@@ -54,7 +74,7 @@ var executeCommand = &command.Command{
 
 func execute(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	_ output.Logger,
 	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
@@ -66,6 +86,23 @@ func execute(
 		return nil, fmt.Errorf("error loading script file: %w", err)
 	}
 
+	if flags.Watch {
+		if flags.Heights != "" || len(flags.AtHeights) > 0 || flags.BlockHeight != 0 || flags.BlockID != "" {
+			return nil, fmt.Errorf("watch can not be combined with a block height, block ID or multiple heights")
+		}
+
+		// configuration is optional and only used to resolve the imports to watch
+		state, err := signer.Load(globalFlags.ConfigPaths, readerWriter)
+		if err != nil && !errors.Is(err, config.ErrDoesNotExist) {
+			return nil, err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		return nil, watchScript(ctx, os.Stdout, filename, args[1:], readerWriter, state, flow, flags)
+	}
+
 	return SendScript(code, args[1:], filename, flow, flags)
 }
 
@@ -82,6 +119,35 @@ func SendScript(code []byte, argsArr []string, location string, flow flowkit.Ser
 		return nil, fmt.Errorf("error parsing script arguments: %w", err)
 	}
 
+	heights, err := parseHeights(scriptFlags.Heights, scriptFlags.AtHeights)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(heights) > 0 {
+		if scriptFlags.BlockHeight != 0 || scriptFlags.BlockID != "" {
+			return nil, fmt.Errorf("block height or block ID can not be combined with multiple heights")
+		}
+		if scriptFlags.Expect != "" {
+			return nil, fmt.Errorf("expected result can not be combined with multiple heights")
+		}
+
+		return executeAtHeights(
+			flow,
+			flowkit.Script{Code: code, Args: cadenceArgs, Location: location},
+			heights,
+			scriptFlags.ChangesOnly,
+		), nil
+	}
+
+	var expected cadence.Value
+	if scriptFlags.Expect != "" {
+		expected, err = jsoncdc.Decode(nil, []byte(scriptFlags.Expect))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing expected result: %w", err)
+		}
+	}
+
 	query := flowkit.ScriptQuery{}
 	if scriptFlags.BlockHeight != 0 {
 		query.Height = scriptFlags.BlockHeight
@@ -104,5 +170,10 @@ func SendScript(code []byte, argsArr []string, location string, flow flowkit.Ser
 		return nil, err
 	}
 
-	return &scriptResult{value}, nil
+	result := &scriptResult{Value: value}
+	if expected != nil {
+		result.failure = compareResult(expected, value)
+	}
+
+	return result, nil
 }
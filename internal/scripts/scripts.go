@@ -27,6 +27,8 @@ import (
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flowkit/v2/output"
+
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -39,10 +41,12 @@ var Cmd = &cobra.Command{
 
 func init() {
 	executeCommand.AddToParent(Cmd)
+	executeManyCommand.AddToParent(Cmd)
 }
 
 type scriptResult struct {
 	cadence.Value
+	failure string
 }
 
 func NewScriptResult(value cadence.Value) *scriptResult {
@@ -61,6 +65,10 @@ func (r *scriptResult) String() string {
 
 	_, _ = fmt.Fprintf(writer, "Result: %s\n", r.Value)
 
+	if r.failure != "" {
+		_, _ = fmt.Fprintf(writer, "\n%s Expectation failed, result doesn't match the expected value:\n%s", output.ErrorEmoji(), r.failure)
+	}
+
 	_ = writer.Flush()
 
 	return b.String()
@@ -69,3 +77,31 @@ func (r *scriptResult) String() string {
 func (r *scriptResult) Oneliner() string {
 	return r.Value.String()
 }
+
+// ExitCode is non-zero when the result doesn't match the expected value.
+func (r *scriptResult) ExitCode() int {
+	if r.failure != "" {
+		return 1
+	}
+	return 0
+}
+
+// compareResult compares the JSON-Cadence encoding of both values and returns
+// a diff of the expected and actual value if they are not equal.
+func compareResult(expected cadence.Value, actual cadence.Value) string {
+	expectedJSON := indentJSON(jsoncdc.MustEncode(expected))
+	actualJSON := indentJSON(jsoncdc.MustEncode(actual))
+	if expectedJSON == actualJSON {
+		return ""
+	}
+
+	return util.LineDiff(expectedJSON, actualJSON)
+}
+
+func indentJSON(value []byte) string {
+	var b bytes.Buffer
+	if err := json.Indent(&b, value, "", "  "); err != nil {
+		return string(value)
+	}
+	return b.String()
+}
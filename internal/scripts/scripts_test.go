@@ -19,12 +19,21 @@
 package scripts
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flowkit/v2"
 	"github.com/onflow/flowkit/v2/tests"
@@ -50,6 +59,84 @@ func Test_Execute(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Expected result", func(t *testing.T) {
+		inArgs := []string{tests.ScriptArgString.Filename, "foo"}
+		srv.ExecuteScript.Return(cadence.NewInt(1), nil)
+
+		flags.Expect = `{"type":"Int","value":"1"}`
+		result, err := execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.(command.ResultWithExitCode).ExitCode())
+
+		flags.Expect = `{"type":"Int","value":"2"}`
+		result, err = execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.(command.ResultWithExitCode).ExitCode())
+		assert.Contains(t, result.String(), `-   "value": "2"`)
+		assert.Contains(t, result.String(), `+   "value": "1"`)
+
+		flags.Expect = "invalid"
+		_, err = execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "error parsing expected result")
+		flags.Expect = ""
+	})
+
+	t.Run("Multiple heights", func(t *testing.T) {
+		inArgs := []string{tests.ScriptArgString.Filename, "foo"}
+		srv.ExecuteScript.Run(func(mock.Arguments) {}).Return(
+			func(_ context.Context, _ flowkit.Script, query flowkit.ScriptQuery) (cadence.Value, error) {
+				if query.Height == 500 {
+					return nil, fmt.Errorf("state pruned")
+				}
+				if query.Height < 300 {
+					return cadence.NewInt(1), nil
+				}
+				return cadence.NewInt(2), nil
+			},
+		)
+
+		flags.Heights = "100:500:100"
+		result, err := execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Len(t, result.(*seriesResult).entries, 5)
+		assert.Equal(t, 1, result.(command.ResultWithExitCode).ExitCode())
+
+		flags.ChangesOnly = true
+		result, err = execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Equal(t, "100: 1, 300: 2, 500: error", result.Oneliner())
+
+		flags.Heights = ""
+		flags.AtHeights = []string{"200", "300"}
+		result, err = execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Equal(t, "200: 1, 300: 2", result.Oneliner())
+
+		flags.Heights = "300:100"
+		_, err = execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "only one of the heights or at-heights flags can be provided")
+
+		flags.AtHeights = nil
+		_, err = execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "cannot have end height (100) of heights range less than start height (300)")
+
+		flags.Heights = "1:1000000000"
+		_, err = execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "heights range 1:1000000000 is too large, at most 10000 heights can be provided")
+
+		flags.Heights = "0:100"
+		_, err = execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "heights range start must be greater than zero")
+
+		flags.Heights = ""
+		flags.AtHeights = []string{"0", "100"}
+		_, err = execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid block height 0, heights must be greater than zero")
+
+		flags.AtHeights = nil
+		flags.ChangesOnly = false
+	})
+
 	t.Run("Fail non-existing file", func(t *testing.T) {
 		inArgs := []string{"non-existing"}
 		result, err := execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
@@ -67,3 +154,120 @@ func Test_Execute(t *testing.T) {
 	})
 
 }
+
+func Test_ExecuteMany(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	require.NoError(t, rw.WriteFile("inputs.txt", []byte("# names\nfoo\n\nbar\nfail\n"), 0644))
+
+	srv.ExecuteScript.Run(func(mock.Arguments) {}).Return(
+		func(_ context.Context, script flowkit.Script, _ flowkit.ScriptQuery) (cadence.Value, error) {
+			if script.Args[0].String() == `"hello fail"` {
+				return nil, fmt.Errorf("script panicked")
+			}
+			return script.Args[0], nil
+		},
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		executeManyFlags.Inputs = "inputs.txt"
+		executeManyFlags.ResultsFormat = "csv"
+		inArgs := []string{tests.ScriptArgString.Filename, "hello {{input}}"}
+
+		result, err := executeMany(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.(command.ResultWithExitCode).ExitCode())
+		assert.Equal(t, "input,value,error\nfoo,\"\"\"hello foo\"\"\",\nbar,\"\"\"hello bar\"\"\",\nfail,,script panicked", result.String())
+
+		executeManyFlags.ResultsFormat = "jsonl"
+		result, err = executeMany(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		lines := strings.Split(result.String(), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, `{"input":"foo","value":{"value":"hello foo","type":"String"}}`, lines[0])
+		assert.Equal(t, `{"error":"script panicked","input":"fail"}`, lines[2])
+	})
+
+	t.Run("Success escaping JSON arguments", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("escaped.txt", []byte(`C:\path\to`+"\n"), 0644))
+		executeManyFlags.Inputs = "escaped.txt"
+		executeManyFlags.ResultsFormat = "jsonl"
+		executeManyFlags.ArgsJSON = `[{"type":"String","value":"{{input}}"}]`
+		inArgs := []string{tests.ScriptArgString.Filename}
+
+		result, err := executeMany(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, `{"input":"C:\\path\\to","value":{"value":"C:\\path\\to","type":"String"}}`, result.String())
+		executeManyFlags.ArgsJSON = ""
+	})
+
+	t.Run("Fail missing inputs", func(t *testing.T) {
+		executeManyFlags.Inputs = ""
+
+		_, err := executeMany([]string{tests.ScriptArgString.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "inputs file is required, use --inputs flag")
+	})
+
+	executeManyFlags = flagsExecuteMany{Concurrency: 10, ResultsFormat: "jsonl"}
+}
+
+func Test_Watch(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+	rw := &afero.Afero{Fs: afero.NewOsFs()}
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "script.cdc")
+	require.NoError(t, rw.WriteFile(filename, []byte(`
+		import Foo from "./Foo.cdc"
+		access(all) fun main(): Int { return Foo.value }
+	`), 0644))
+	require.NoError(t, rw.WriteFile(filepath.Join(dir, "Foo.cdc"), []byte(`
+		import "Bar"
+		access(all) contract Foo { access(all) let value: Int; init() { self.value = 1 } }
+	`), 0644))
+
+	t.Run("Import files", func(t *testing.T) {
+		code, err := rw.ReadFile(filename)
+		require.NoError(t, err)
+
+		files := importFiles(code, filename, nil, rw, make(map[string]bool))
+		assert.Equal(t, []string{filepath.Join(dir, "Foo.cdc")}, files)
+	})
+
+	t.Run("Re-execute on new blocks", func(t *testing.T) {
+		watchInterval = 10 * time.Millisecond
+		defer func() { watchInterval = time.Second }()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var height atomic.Uint64
+		srv.GetBlock.Return(
+			func(context.Context, flowkit.BlockQuery) (*flow.Block, error) {
+				block := tests.NewBlock()
+				block.Height = height.Add(1)
+				return block, nil
+			},
+		)
+
+		var executions atomic.Int64
+		srv.ExecuteScript.Run(func(mock.Arguments) {}).Return(
+			func(context.Context, flowkit.Script, flowkit.ScriptQuery) (cadence.Value, error) {
+				count := executions.Add(1)
+				if count >= 4 {
+					cancel()
+				}
+				if count < 3 {
+					return cadence.NewInt(1), nil
+				}
+				return cadence.NewInt(2), nil
+			},
+		)
+
+		var out bytes.Buffer
+		err := watchScript(ctx, &out, filename, nil, rw, nil, srv.Mock, Flags{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, strings.Count(out.String(), "Result: 1"))
+		assert.Equal(t, 1, strings.Count(out.String(), "Result: 2"))
+	})
+}
@@ -0,0 +1,231 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flowkit/v2"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// seriesWorkers is the number of scripts executed concurrently when running at multiple heights.
+const seriesWorkers = 10
+
+// maxSeriesHeights is the maximum number of heights a script can be executed at in one run.
+const maxSeriesHeights = 10000
+
+// parseHeights returns the block heights from the range in the format "start:end[:step]"
+// or from the list of heights.
+func parseHeights(heightsRange string, atHeights []string) ([]uint64, error) {
+	if heightsRange != "" && len(atHeights) > 0 {
+		return nil, fmt.Errorf("only one of the heights or at-heights flags can be provided")
+	}
+
+	heights := make([]uint64, 0)
+	for _, value := range atHeights {
+		height, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block height %s", value)
+		}
+		// a zero height query means the latest block, so it can't be used to address the root block
+		if height == 0 {
+			return nil, fmt.Errorf("invalid block height 0, heights must be greater than zero")
+		}
+		heights = append(heights, height)
+	}
+	if len(heights) > maxSeriesHeights {
+		return nil, fmt.Errorf("too many heights, at most %d heights can be provided", maxSeriesHeights)
+	}
+
+	if heightsRange == "" {
+		return heights, nil
+	}
+
+	parts := strings.Split(heightsRange, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid heights range %s, use start:end or start:end:step", heightsRange)
+	}
+
+	bounds := make([]uint64, 0, 3)
+	for _, part := range parts {
+		value, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid heights range %s, use start:end or start:end:step", heightsRange)
+		}
+		bounds = append(bounds, value)
+	}
+
+	start, end, step := bounds[0], bounds[1], uint64(1)
+	if len(bounds) == 3 {
+		step = bounds[2]
+	}
+	if step == 0 {
+		return nil, fmt.Errorf("heights range step must be greater than zero")
+	}
+	if start == 0 {
+		return nil, fmt.Errorf("heights range start must be greater than zero")
+	}
+	if end < start {
+		return nil, fmt.Errorf("cannot have end height (%d) of heights range less than start height (%d)", end, start)
+	}
+	if (end-start)/step >= maxSeriesHeights {
+		return nil, fmt.Errorf("heights range %s is too large, at most %d heights can be provided", heightsRange, maxSeriesHeights)
+	}
+
+	for height := start; height <= end; height += step {
+		heights = append(heights, height)
+		if height+step < height { // overflow
+			break
+		}
+	}
+
+	return heights, nil
+}
+
+// seriesEntry is the result of the script executed at a block height.
+type seriesEntry struct {
+	height uint64
+	value  cadence.Value
+	err    error
+}
+
+// encoded returns the value in JSON-Cadence encoding or the error, and is used to detect changes between heights.
+func (e seriesEntry) encoded() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return string(jsoncdc.MustEncode(e.value))
+}
+
+// executeAtHeights executes the script at each of the heights concurrently. Failures at a height,
+// for example because the state was pruned, are recorded in the series instead of stopping the execution.
+func executeAtHeights(
+	flow flowkit.Services,
+	script flowkit.Script,
+	heights []uint64,
+	changesOnly bool,
+) *seriesResult {
+	entries := make([]seriesEntry, len(heights))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(seriesWorkers, len(heights)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				value, err := flow.ExecuteScript(
+					context.Background(),
+					script,
+					flowkit.ScriptQuery{Height: heights[index]},
+				)
+				entries[index] = seriesEntry{height: heights[index], value: value, err: err}
+			}
+		}()
+	}
+
+	for index := range heights {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	if changesOnly {
+		changed := make([]seriesEntry, 0)
+		for i, entry := range entries {
+			if i == 0 || entry.encoded() != entries[i-1].encoded() {
+				changed = append(changed, entry)
+			}
+		}
+		entries = changed
+	}
+
+	return &seriesResult{entries: entries}
+}
+
+type seriesResult struct {
+	entries []seriesEntry
+}
+
+func (r *seriesResult) JSON() any {
+	result := make([]any, 0, len(r.entries))
+	for _, entry := range r.entries {
+		item := map[string]any{"height": entry.height}
+		if entry.err != nil {
+			item["error"] = entry.err.Error()
+		} else {
+			item["value"] = json.RawMessage(jsoncdc.MustEncode(entry.value))
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func (r *seriesResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Height\tResult\n")
+	for _, entry := range r.entries {
+		if entry.err != nil {
+			_, _ = fmt.Fprintf(writer, "%d\tError: %s\n", entry.height, entry.err.Error())
+		} else {
+			_, _ = fmt.Fprintf(writer, "%d\t%s\n", entry.height, entry.value)
+		}
+	}
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *seriesResult) Oneliner() string {
+	values := make([]string, 0, len(r.entries))
+	for _, entry := range r.entries {
+		if entry.err != nil {
+			values = append(values, fmt.Sprintf("%d: error", entry.height))
+		} else {
+			values = append(values, fmt.Sprintf("%d: %s", entry.height, entry.value))
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// ExitCode is non-zero when the script failed at any of the heights.
+func (r *seriesResult) ExitCode() int {
+	for _, entry := range r.entries {
+		if entry.err != nil {
+			return 1
+		}
+	}
+	return 0
+}
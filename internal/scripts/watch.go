@@ -0,0 +1,191 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// watchInterval is how often the latest sealed block is checked for changes.
+var watchInterval = time.Second
+
+// watchScript executes the script every time a new sealed block appears or the script file or any of its
+// imports change on disk, and writes the result to the output only when it differs from the previous one.
+//
+// It returns when the context is done.
+func watchScript(
+	ctx context.Context,
+	out io.Writer,
+	filename string,
+	argsArr []string,
+	readerWriter flowkit.ReaderWriter,
+	state *flowkit.State,
+	flow flowkit.Services,
+	scriptFlags Flags,
+) error {
+	files := util.NewFileWatcher()
+	defer files.Close()
+
+	if err := files.Add(filename); err != nil {
+		return fmt.Errorf("failed to watch script file: %w", err)
+	}
+
+	watched := map[string]bool{filename: true}
+	lastOutput := ""
+	lastHeight := uint64(0)
+
+	latestHeight := func() (uint64, error) {
+		block, err := flow.GetBlock(ctx, flowkit.BlockQuery{Latest: true})
+		if err != nil {
+			return 0, err
+		}
+		return block.Height, nil
+	}
+
+	run := func(height uint64) {
+		lastHeight = height
+
+		code, err := readerWriter.ReadFile(filename)
+		if err != nil {
+			lastOutput = ""
+			_, _ = fmt.Fprintf(out, "%s error loading script file: %s\n", output.ErrorEmoji(), err)
+			return
+		}
+
+		// watch imports added since the last execution, imports that can't be resolved are reported by the execution
+		for _, file := range importFiles(code, filename, state, readerWriter, make(map[string]bool)) {
+			if !watched[file] && files.Add(file) == nil {
+				watched[file] = true
+			}
+		}
+
+		result, err := SendScript(code, argsArr, filename, flow, scriptFlags)
+		var text string
+		if err != nil {
+			text = fmt.Sprintf("%s %s", output.ErrorEmoji(), err)
+		} else {
+			text = result.String()
+		}
+
+		if text == lastOutput {
+			return
+		}
+		lastOutput = text
+		_, _ = fmt.Fprintf(out, "[%s] Block height %d\n%s\n", time.Now().Format(time.TimeOnly), lastHeight, strings.TrimSuffix(text, "\n"))
+	}
+
+	height, err := latestHeight()
+	if err != nil {
+		return err
+	}
+	run(height)
+
+	files.Start()
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-files.Errors():
+			return fmt.Errorf("failed to watch files: %w", err)
+		case <-files.Events():
+			// file changes don't wait for a new block, so the height is resolved again
+			height, err := latestHeight()
+			if err != nil {
+				height = lastHeight
+			}
+			run(height)
+		case <-ticker.C:
+			height, err := latestHeight()
+			if err != nil {
+				// the network might be temporarily unavailable, so we keep watching
+				continue
+			}
+			if height > lastHeight {
+				run(height)
+			}
+		}
+	}
+}
+
+// importFiles returns the paths of the local files the code imports, including the imports of the imported files.
+func importFiles(
+	code []byte,
+	location string,
+	state *flowkit.State,
+	readerWriter flowkit.ReaderWriter,
+	visited map[string]bool,
+) []string {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil || program == nil {
+		return nil
+	}
+
+	files := make([]string, 0)
+	for _, declaration := range program.ImportDeclarations() {
+		importLocation, ok := declaration.Location.(common.StringLocation)
+		if !ok { // address imports are not local files
+			continue
+		}
+
+		var path string
+		if strings.Contains(importLocation.String(), ".cdc") {
+			path = filepath.Join(filepath.Dir(location), importLocation.String())
+		} else {
+			if state == nil {
+				continue
+			}
+			contract, err := state.Contracts().ByName(importLocation.String())
+			if err != nil {
+				continue
+			}
+			path = contract.Location
+		}
+
+		if visited[path] {
+			continue
+		}
+		visited[path] = true
+		files = append(files, path)
+
+		importedCode, err := readerWriter.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		files = append(files, importFiles(importedCode, path, state, readerWriter, visited)...)
+	}
+
+	return files
+}
@@ -0,0 +1,137 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsAccountProofGenerate struct {
+	Signer string `default:"emulator-account" flag:"signer" info:"name of the account used to sign"`
+	AppID  string `default:"" flag:"app-id" info:"Application identifier the proof is generated for"`
+	Nonce  string `default:"" flag:"nonce" info:"Hex encoded nonce of at least 32 bytes provided by the application"`
+}
+
+var accountProofGenerateFlags = flagsAccountProofGenerate{}
+
+var accountProofGenerateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "generate",
+		Short:   "Generate an account proof as created by FCL wallets",
+		Example: "flow signatures account-proof generate --signer alice --app-id 'My App' --nonce 75f8587e5bd5f9dcc9909d0dae1f0ac5814458b2ae129620502cb936fde7120a",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &accountProofGenerateFlags,
+	RunS:  generateAccountProof,
+}
+
+func generateAccountProof(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	nonce, err := decodeAccountProofNonce(accountProofGenerateFlags.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := state.Accounts().ByName(accountProofGenerateFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := encodeAccountProof(accountProofGenerateFlags.AppID, account.Address, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := account.Key.Signer(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+
+	address := fmt.Sprintf("0x%s", account.Address.Hex())
+	return &accountProofResult{
+		appID:   accountProofGenerateFlags.AppID,
+		message: message,
+		proof: accountProof{
+			Address: address,
+			Nonce:   hex.EncodeToString(nonce),
+			Signatures: []compositeSignature{{
+				FType:     compositeSignatureType,
+				FVsn:      compositeSignatureVersion,
+				Addr:      address,
+				KeyID:     account.Key.Index(),
+				Signature: hex.EncodeToString(signature),
+			}},
+		},
+	}, nil
+}
+
+type accountProofResult struct {
+	appID   string
+	message []byte
+	proof   accountProof
+}
+
+func (r *accountProofResult) JSON() any {
+	return r.proof
+}
+
+func (r *accountProofResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address \t %s\n", r.proof.Address)
+	_, _ = fmt.Fprintf(writer, "App ID \t %s\n", r.appID)
+	_, _ = fmt.Fprintf(writer, "Nonce \t %s\n", r.proof.Nonce)
+	_, _ = fmt.Fprintf(writer, "Encoded Message \t %x\n", r.message)
+	for _, sig := range r.proof.Signatures {
+		_, _ = fmt.Fprintf(writer, "Key Index \t %d\n", sig.KeyID)
+		_, _ = fmt.Fprintf(writer, "Signature \t %s\n", sig.Signature)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *accountProofResult) Oneliner() string {
+	return fmt.Sprintf(
+		"address: %s, nonce: %s, keyId: %d, signature: %s",
+		r.proof.Address, r.proof.Nonce, r.proof.Signatures[0].KeyID, r.proof.Signatures[0].Signature,
+	)
+}
@@ -0,0 +1,167 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsAccountProofVerify struct {
+	AppID string `default:"" flag:"app-id" info:"Application identifier the proof was generated for"`
+	Nonce string `default:"" flag:"nonce" info:"Expected hex encoded nonce, verified against the nonce in the proof"`
+}
+
+var accountProofVerifyFlags = flagsAccountProofVerify{}
+
+var accountProofVerifyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "verify <proof filename>",
+		Short:   "Verify an FCL account proof against the on-chain account keys",
+		Example: "flow signatures account-proof verify ./proof.json --app-id 'My App'",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &accountProofVerifyFlags,
+	Run:   verifyAccountProof,
+}
+
+func verifyAccountProof(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	content, err := readerWriter.ReadFile(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account proof from %s: %w", args[0], err)
+	}
+
+	var proof accountProof
+	if err := json.Unmarshal(content, &proof); err != nil {
+		return nil, fmt.Errorf("invalid account proof: %w", err)
+	}
+
+	nonce, err := decodeAccountProofNonce(proof.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountProofVerifyFlags.Nonce != "" {
+		expected, err := hex.DecodeString(strings.TrimPrefix(accountProofVerifyFlags.Nonce, "0x"))
+		if err != nil || !bytes.Equal(expected, nonce) {
+			return nil, fmt.Errorf("account proof nonce %s doesn't match the expected nonce", proof.Nonce)
+		}
+	}
+
+	if len(proof.Signatures) == 0 {
+		return nil, fmt.Errorf("account proof doesn't contain any signatures")
+	}
+
+	address := flowsdk.HexToAddress(proof.Address)
+	signatures := make([]*accountSignature, 0, len(proof.Signatures))
+	indexes := make(map[uint32]bool)
+	for _, sig := range proof.Signatures {
+		if flowsdk.HexToAddress(sig.Addr) != address {
+			return nil, fmt.Errorf("signature address %s doesn't match the proof address %s", sig.Addr, proof.Address)
+		}
+		if indexes[sig.KeyID] {
+			return nil, fmt.Errorf("duplicate signature for key index %d", sig.KeyID)
+		}
+		indexes[sig.KeyID] = true
+
+		signature, err := hex.DecodeString(strings.TrimPrefix(sig.Signature, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature for key index %d: %w", sig.KeyID, err)
+		}
+		signatures = append(signatures, &accountSignature{keyIndex: sig.KeyID, signature: signature})
+	}
+
+	message, err := encodeAccountProof(accountProofVerifyFlags.AppID, address, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading account %s...", address))
+	account, err := flow.GetAccount(context.Background(), address)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	verifications, err := verifyAccountSignatures(account, message, signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountProofVerificationResult{
+		accountVerificationResult: &accountVerificationResult{
+			address:       address,
+			message:       message,
+			verifications: verifications,
+		},
+		appID: accountProofVerifyFlags.AppID,
+		nonce: proof.Nonce,
+	}, nil
+}
+
+type accountProofVerificationResult struct {
+	*accountVerificationResult
+	appID string
+	nonce string
+}
+
+// the exit code of the account verification is non-zero when the proof is invalid
+var _ command.ResultWithExitCode = &accountProofVerificationResult{}
+
+func (r *accountProofVerificationResult) JSON() any {
+	result := r.accountVerificationResult.JSON().(map[string]any)
+	delete(result, "message")
+	result["appId"] = r.appID
+	result["nonce"] = r.nonce
+
+	return result
+}
+
+func (r *accountProofVerificationResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Valid \t %v\n", r.valid())
+	_, _ = fmt.Fprintf(writer, "Address \t 0x%s\n", r.address.Hex())
+	_, _ = fmt.Fprintf(writer, "App ID \t %s\n", r.appID)
+	_, _ = fmt.Fprintf(writer, "Nonce \t %s\n", r.nonce)
+	r.writeKeys(writer)
+
+	_ = writer.Flush()
+	return b.String()
+}
@@ -0,0 +1,95 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signatures
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/go-ethereum/rlp"
+	"github.com/spf13/cobra"
+)
+
+var accountProofCmd = &cobra.Command{
+	Use:              "account-proof",
+	Short:            "Generate and verify FCL account proofs",
+	Example:          "flow signatures account-proof generate --signer alice --app-id 'My App' --nonce 75f8...",
+	TraverseChildren: true,
+}
+
+func init() {
+	accountProofGenerateCommand.AddToParent(accountProofCmd)
+	accountProofVerifyCommand.AddToParent(accountProofCmd)
+}
+
+// accountProofDomainTag is the domain tag FCL uses for account proofs.
+var accountProofDomainTag = mustPadDomainTag("FCL-ACCOUNT-PROOF-V0.0")
+
+// minAccountProofNonceLength is the minimum nonce length in bytes accepted by FCL.
+const minAccountProofNonceLength = 32
+
+const (
+	compositeSignatureType    = "CompositeSignature"
+	compositeSignatureVersion = "1.0.0"
+)
+
+// accountProof is the account proof data as provided by FCL wallets.
+type accountProof struct {
+	Address    string               `json:"address"`
+	Nonce      string               `json:"nonce"`
+	Signatures []compositeSignature `json:"signatures"`
+}
+
+type compositeSignature struct {
+	FType     string `json:"f_type"`
+	FVsn      string `json:"f_vsn"`
+	Addr      string `json:"addr"`
+	KeyID     uint32 `json:"keyId"`
+	Signature string `json:"signature"`
+}
+
+// decodeAccountProofNonce decodes the hex nonce and validates its length.
+func decodeAccountProofNonce(nonce string) ([]byte, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(nonce, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce, must be hex encoded: %w", err)
+	}
+	if len(decoded) < minAccountProofNonceLength {
+		return nil, fmt.Errorf("invalid nonce, must be at least %d bytes", minAccountProofNonceLength)
+	}
+
+	return decoded, nil
+}
+
+// encodeAccountProof encodes the account proof message signed by the account, the same way FCL does:
+// domain tag followed by the RLP encoded list of app identifier, address and nonce.
+func encodeAccountProof(appID string, address flowsdk.Address, nonce []byte) ([]byte, error) {
+	if appID == "" {
+		return nil, fmt.Errorf("app identifier must be provided")
+	}
+
+	encoded, err := rlp.EncodeToBytes([]any{[]byte(appID), address.Bytes(), nonce})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode account proof: %w", err)
+	}
+
+	return append(append([]byte{}, accountProofDomainTag...), encoded...), nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signatures
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flowkit/v2"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const signatureDocumentVersion = 1
+
+// signatureDocument is a detached signature of a file, it is stored separately from the signed file.
+type signatureDocument struct {
+	Version   int    `json:"version"`
+	File      string `json:"file"`
+	Address   string `json:"address"`
+	KeyIndex  uint32 `json:"keyIndex"`
+	SigAlgo   string `json:"sigAlgo"`
+	HashAlgo  string `json:"hashAlgo"`
+	DomainTag string `json:"domainTag"`
+	Signature string `json:"signature"`
+}
+
+// digestHasher is a hasher returning a digest computed in advance, it allows signing and verifying
+// streamed content without loading it in memory since the message passed to it is ignored.
+type digestHasher struct {
+	crypto.Hasher
+	digest crypto.Hash
+}
+
+func (h *digestHasher) ComputeHash([]byte) crypto.Hash {
+	return h.digest
+}
+
+// hashFile streams the domain tag followed by the file content through a hasher of the provided algorithm.
+func hashFile(
+	rw flowkit.ReaderWriter,
+	filename string,
+	tag []byte,
+	hashAlgo crypto.HashAlgorithm,
+) (*digestHasher, error) {
+	hasher, err := crypto.NewHasher(hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := util.OpenFile(rw, filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filename, err)
+	}
+	defer file.Close()
+
+	_, _ = hasher.Write(tag)
+	if _, err := io.Copy(hasher, file); err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	return &digestHasher{Hasher: hasher, digest: hasher.SumHash()}, nil
+}
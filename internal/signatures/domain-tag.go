@@ -0,0 +1,76 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signatures
+
+import (
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+)
+
+const (
+	domainTagNone        = "none"
+	domainTagUser        = "user"
+	domainTagTransaction = "transaction"
+	domainTagCustom      = "custom:"
+)
+
+// domainTagLength is the length domain tags are right padded to.
+const domainTagLength = 32
+
+// padDomainTag returns the domain tag encoded as UTF-8 bytes and right padded with zeros to the domain tag length.
+func padDomainTag(tag string) ([]byte, error) {
+	if len(tag) > domainTagLength {
+		return nil, fmt.Errorf("domain tag %s can not be longer than %d characters", tag, domainTagLength)
+	}
+
+	padded := make([]byte, domainTagLength)
+	copy(padded, tag)
+	return padded, nil
+}
+
+func mustPadDomainTag(tag string) []byte {
+	padded, err := padDomainTag(tag)
+	if err != nil {
+		panic(err)
+	}
+
+	return padded
+}
+
+// domainTag returns the padded domain tag with the provided name, the name is none, user,
+// transaction or a custom tag in the custom:<tag> format.
+func domainTag(name string) ([]byte, error) {
+	switch {
+	case name == domainTagNone || name == "":
+		return nil, nil
+	case name == domainTagUser:
+		return append([]byte{}, flowsdk.UserDomainTag[:]...), nil
+	case name == domainTagTransaction:
+		return append([]byte{}, flowsdk.TransactionDomainTag[:]...), nil
+	case strings.HasPrefix(name, domainTagCustom) && len(name) > len(domainTagCustom):
+		return padDomainTag(strings.TrimPrefix(name, domainTagCustom))
+	default:
+		return nil, fmt.Errorf(
+			"invalid domain tag %s, valid values are: %s, %s, %s or %s<tag>",
+			name, domainTagNone, domainTagUser, domainTagTransaction, domainTagCustom,
+		)
+	}
+}
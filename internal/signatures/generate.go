@@ -21,10 +21,14 @@ package signatures
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 
 	"github.com/onflow/flowkit/v2/accounts"
 
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flowkit/v2"
@@ -35,17 +39,19 @@ import (
 )
 
 type flagsGenerate struct {
-	Signer string `default:"emulator-account" flag:"signer" info:"name of the account used to sign"`
+	Signer    string `default:"emulator-account" flag:"signer" info:"name of the account used to sign"`
+	File      string `default:"" flag:"file" info:"Sign the file and output a detached signature document, the file is loaded in memory for keys without private key access like KMS keys"`
+	DomainTag string `default:"none" flag:"domain-tag" info:"Domain tag prepended to the signed data: none, user, transaction or custom:<tag>"`
 }
 
 var generateFlags = flagsGenerate{}
 
 var generateCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "generate <message>",
-		Short:   "Generate the message signature",
-		Example: "flow signatures generate 'The quick brown fox jumps over the lazy dog' --signer alice",
-		Args:    cobra.ExactArgs(1),
+		Use:     "generate [<message> | --file <filename>]",
+		Short:   "Generate the message or file signature",
+		Example: "flow signatures generate 'The quick brown fox jumps over the lazy dog' --signer alice\nflow signatures generate --file ./release.tar.gz --signer alice --domain-tag user --save release.tar.gz.sig",
+		Args:    cobra.MaximumNArgs(1),
 	},
 	Flags: &generateFlags,
 	RunS:  sign,
@@ -58,34 +64,125 @@ func sign(
 	_ flowkit.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
-	message := []byte(args[0])
+	if generateFlags.File != "" && len(args) > 0 {
+		return nil, fmt.Errorf("only use one, message argument or --file <filename>")
+	}
+	if generateFlags.File == "" && len(args) == 0 {
+		return nil, fmt.Errorf("message argument is required")
+	}
+
 	accountName := generateFlags.Signer
 	acc, err := state.Accounts().ByName(accountName)
 	if err != nil {
 		return nil, err
 	}
 
+	tag, err := domainTag(generateFlags.DomainTag)
+	if err != nil {
+		return nil, err
+	}
+
+	if generateFlags.File != "" {
+		return signFile(acc, state.ReaderWriter(), generateFlags.File, tag)
+	}
+
+	message := []byte(args[0])
 	s, err := acc.Key.Signer(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
-	signed, err := s.Sign(message)
+	signed, err := s.Sign(append(tag, message...))
 	if err != nil {
 		return nil, err
 	}
 
 	return &signatureResult{
-		result:  string(signed),
-		message: string(message),
-		key:     acc.Key,
+		result:    string(signed),
+		message:   string(message),
+		key:       acc.Key,
+		publicKey: s.PublicKey(),
 	}, nil
 }
 
+// signFile signs the domain tag followed by the file content and returns a detached signature document.
+func signFile(
+	acc *accounts.Account,
+	rw flowkit.ReaderWriter,
+	filename string,
+	tag []byte,
+) (command.Result, error) {
+	var signature []byte
+
+	privateKey, err := acc.Key.PrivateKey()
+	if err == nil {
+		hasher, err := hashFile(rw, filename, tag, acc.Key.HashAlgo())
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err = (*privateKey).Sign(nil, hasher)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// keys without private key access (e.g. KMS) can only sign the content in memory
+		content, err := rw.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+		}
+
+		s, err := acc.Key.Signer(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err = s.Sign(append(tag, content...))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &signatureDocumentResult{
+		document: signatureDocument{
+			Version:   signatureDocumentVersion,
+			File:      filepath.Base(filename),
+			Address:   fmt.Sprintf("0x%s", acc.Address.Hex()),
+			KeyIndex:  acc.Key.Index(),
+			SigAlgo:   acc.Key.SigAlgo().String(),
+			HashAlgo:  acc.Key.HashAlgo().String(),
+			DomainTag: generateFlags.DomainTag,
+			Signature: hex.EncodeToString(signature),
+		},
+	}, nil
+}
+
+type signatureDocumentResult struct {
+	document signatureDocument
+}
+
+func (s *signatureDocumentResult) JSON() any {
+	return s.document
+}
+
+// String returns the signature document so it can be saved directly as the detached signature.
+func (s *signatureDocumentResult) String() string {
+	document, _ := json.MarshalIndent(s.document, "", "  ")
+	return fmt.Sprintf("%s\n", document)
+}
+
+func (s *signatureDocumentResult) Oneliner() string {
+	return fmt.Sprintf(
+		"file: %s, address: %s, keyIndex: %d, signature: %s",
+		s.document.File, s.document.Address, s.document.KeyIndex, s.document.Signature,
+	)
+}
+
 type signatureResult struct {
-	result  string
-	message string
-	key     accounts.Key
+	result    string
+	message   string
+	key       accounts.Key
+	publicKey crypto.PublicKey
 }
 
 func (s *signatureResult) pubKey() string {
@@ -94,6 +191,11 @@ func (s *signatureResult) pubKey() string {
 		return (*pkey).PublicKey().String()
 	}
 
+	// keys without private key access (e.g. KMS or PKCS#11) get the public key from their signer
+	if s.publicKey != nil {
+		return s.publicKey.String()
+	}
+
 	return "ERR"
 }
 
@@ -32,4 +32,6 @@ var Cmd = &cobra.Command{
 func init() {
 	generateCommand.AddToParent(Cmd)
 	verifyCommand.AddToParent(Cmd)
+	verifyAccountCommand.AddToParent(Cmd)
+	Cmd.AddCommand(accountProofCmd)
 }
@@ -19,9 +19,24 @@
 package signatures
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"testing"
 
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/config"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
@@ -85,3 +100,469 @@ func Test_Sign(t *testing.T) {
 		assert.Nil(t, result)
 	})
 }
+
+// testAccountKey creates an account key and a function signing messages with it using the standard library.
+func testAccountKey(t *testing.T, index uint32, weight int) (*flowsdk.AccountKey, func([]byte) string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	encoded := append(key.PublicKey.X.FillBytes(make([]byte, 32)), key.PublicKey.Y.FillBytes(make([]byte, 32))...)
+	publicKey, err := crypto.DecodePublicKey(crypto.ECDSA_P256, encoded)
+	require.NoError(t, err)
+
+	sign := func(message []byte) string {
+		digest := sha3.Sum256(message)
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		return fmt.Sprintf("%x%x", r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32)))
+	}
+
+	return &flowsdk.AccountKey{
+		Index:     index,
+		PublicKey: publicKey,
+		SigAlgo:   crypto.ECDSA_P256,
+		HashAlgo:  crypto.SHA3_256,
+		Weight:    weight,
+	}, sign
+}
+
+// testKey is an account key signing with the standard library using a key created by testAccountKey.
+type testKey struct {
+	key  *flowsdk.AccountKey
+	sign func([]byte) string
+}
+
+var _ accounts.Key = &testKey{}
+var _ crypto.Signer = &testKey{}
+
+func (k *testKey) Type() config.KeyType {
+	return config.KeyTypeHex
+}
+
+func (k *testKey) Index() uint32 {
+	return k.key.Index
+}
+
+func (k *testKey) SigAlgo() crypto.SignatureAlgorithm {
+	return k.key.SigAlgo
+}
+
+func (k *testKey) HashAlgo() crypto.HashAlgorithm {
+	return k.key.HashAlgo
+}
+
+func (k *testKey) Signer(_ context.Context) (crypto.Signer, error) {
+	return k, nil
+}
+
+func (k *testKey) ToConfig() config.AccountKey {
+	return config.AccountKey{}
+}
+
+func (k *testKey) Validate() error {
+	return nil
+}
+
+func (k *testKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible")
+}
+
+func (k *testKey) PublicKey() crypto.PublicKey {
+	return k.key.PublicKey
+}
+
+func (k *testKey) Sign(message []byte) ([]byte, error) {
+	return hex.DecodeString(k.sign(message))
+}
+
+func Test_VerifyAccount(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	key0, sign0 := testAccountKey(t, 0, 500)
+	key1, sign1 := testAccountKey(t, 1, 500)
+	key2, sign2 := testAccountKey(t, 2, 1000)
+	key2.Revoked = true
+
+	account := &flowsdk.Account{
+		Address: flowsdk.HexToAddress("0x01"),
+		Keys:    []*flowsdk.AccountKey{key0, key1, key2},
+	}
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(account, nil)
+
+	message := "test message"
+	tagged := append(flowsdk.UserDomainTag[:], []byte(message)...)
+	inArgs := []string{"0x01", message}
+
+	t.Run("Success", func(t *testing.T) {
+		verifyAccountFlags = flagsVerifyAccount{
+			Sig:       []string{"0:" + sign0(tagged), "1:0x" + sign1(tagged)},
+			DomainTag: domainTagUser,
+		}
+
+		result, err := verifyAccount(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		verification := result.(*accountVerificationResult)
+		assert.True(t, verification.valid())
+		assert.Equal(t, 1000, verification.weight())
+		assert.Equal(t, 0, verification.ExitCode())
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		verifyAccountFlags = flagsVerifyAccount{
+			Sig:       []string{"0:" + sign0(tagged), "1:" + sign0(tagged)},
+			DomainTag: domainTagUser,
+		}
+
+		result, err := verifyAccount(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		verification := result.(*accountVerificationResult)
+		assert.False(t, verification.valid())
+		assert.Equal(t, 500, verification.weight())
+		assert.Equal(t, 1, verification.ExitCode())
+		assert.False(t, verification.verifications[1].valid)
+	})
+
+	t.Run("Revoked key ignored", func(t *testing.T) {
+		verifyAccountFlags = flagsVerifyAccount{
+			Sig:       []string{"2:" + sign2(tagged)},
+			DomainTag: domainTagUser,
+		}
+
+		result, err := verifyAccount(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		verification := result.(*accountVerificationResult)
+		assert.False(t, verification.valid())
+		assert.True(t, verification.verifications[0].revoked)
+	})
+
+	t.Run("Without domain tag", func(t *testing.T) {
+		verifyAccountFlags = flagsVerifyAccount{
+			Sig:       []string{"0:" + sign0([]byte(message)), "1:" + sign1(tagged)},
+			DomainTag: domainTagNone,
+		}
+
+		result, err := verifyAccount(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		verification := result.(*accountVerificationResult)
+		assert.True(t, verification.verifications[0].valid)
+		assert.False(t, verification.verifications[1].valid)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		tests := []struct {
+			sig []string
+			tag string
+			err string
+		}{{
+			sig: nil,
+			tag: domainTagUser,
+			err: "at least one signature must be provided with the --sig flag",
+		}, {
+			sig: []string{"aaaa"},
+			tag: domainTagUser,
+			err: "invalid signature aaaa, expected format keyIndex:signature",
+		}, {
+			sig: []string{"0:aaaa", "0:bbbb"},
+			tag: domainTagUser,
+			err: "duplicate signature for key index 0",
+		}, {
+			sig: []string{"5:aaaa"},
+			tag: domainTagUser,
+			err: "key index 5 does not exist on account 0000000000000001",
+		}, {
+			sig: []string{"0:aaaa"},
+			tag: "invalid",
+			err: "invalid domain tag invalid, valid values are: none, user, transaction or custom:<tag>",
+		}}
+
+		for _, test := range tests {
+			verifyAccountFlags = flagsVerifyAccount{Sig: test.sig, DomainTag: test.tag}
+			result, err := verifyAccount(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+			assert.EqualError(t, err, test.err)
+			assert.Nil(t, result)
+		}
+	})
+}
+
+func Test_AccountProof(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	appID := "AWESOME-APP-ID"
+	nonce := "3037366134636339643564623330316636626239323161663465346131393662"
+	address := flowsdk.HexToAddress("0xABC123DEF456")
+
+	t.Run("Encode", func(t *testing.T) {
+		decoded, err := decodeAccountProofNonce(nonce)
+		require.NoError(t, err)
+
+		message, err := encodeAccountProof(appID, address, decoded)
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			"46434c2d4143434f554e542d50524f4f462d56302e3000000000000000000000"+
+				"f8398e415745534f4d452d4150502d4944880000abc123def456a03037366134636339643564623330316636626239323161663465346131393662",
+			hex.EncodeToString(message),
+		)
+	})
+
+	key0, sign0 := testAccountKey(t, 0, 1000)
+	key1, _ := testAccountKey(t, 1, 1000)
+	key1.Revoked = true
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(&flowsdk.Account{
+		Address: address,
+		Keys:    []*flowsdk.AccountKey{key0, key1},
+	}, nil)
+
+	decoded, _ := decodeAccountProofNonce(nonce)
+	message, _ := encodeAccountProof(appID, address, decoded)
+
+	writeProof := func(t *testing.T, addr string, keyID uint32, signature string) {
+		proof := accountProof{
+			Address: "0xabc123def456",
+			Nonce:   nonce,
+			Signatures: []compositeSignature{{
+				FType:     compositeSignatureType,
+				FVsn:      compositeSignatureVersion,
+				Addr:      addr,
+				KeyID:     keyID,
+				Signature: signature,
+			}},
+		}
+		content, err := json.Marshal(proof)
+		require.NoError(t, err)
+		require.NoError(t, rw.WriteFile("proof.json", content, 0644))
+	}
+
+	t.Run("Verify", func(t *testing.T) {
+		writeProof(t, "0xabc123def456", 0, sign0(message))
+		accountProofVerifyFlags = flagsAccountProofVerify{AppID: appID, Nonce: nonce}
+
+		result, err := verifyAccountProof([]string{"proof.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.True(t, result.(*accountProofVerificationResult).valid())
+		assert.Equal(t, 0, result.(*accountProofVerificationResult).ExitCode())
+	})
+
+	t.Run("Verify wrong app ID", func(t *testing.T) {
+		writeProof(t, "0xabc123def456", 0, sign0(message))
+		accountProofVerifyFlags = flagsAccountProofVerify{AppID: "OTHER-APP-ID"}
+
+		result, err := verifyAccountProof([]string{"proof.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.False(t, result.(*accountProofVerificationResult).valid())
+		assert.Equal(t, 1, result.(*accountProofVerificationResult).ExitCode())
+	})
+
+	t.Run("Verify revoked key", func(t *testing.T) {
+		writeProof(t, "0xabc123def456", 1, sign0(message))
+		accountProofVerifyFlags = flagsAccountProofVerify{AppID: appID}
+
+		result, err := verifyAccountProof([]string{"proof.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.False(t, result.(*accountProofVerificationResult).valid())
+	})
+
+	t.Run("Verify fail", func(t *testing.T) {
+		writeProof(t, "0x01", 0, sign0(message))
+		accountProofVerifyFlags = flagsAccountProofVerify{AppID: appID}
+		_, err := verifyAccountProof([]string{"proof.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "signature address 0x01 doesn't match the proof address 0xabc123def456")
+
+		writeProof(t, "0xabc123def456", 0, sign0(message))
+		accountProofVerifyFlags = flagsAccountProofVerify{AppID: appID, Nonce: "aaaa"}
+		_, err = verifyAccountProof([]string{"proof.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "account proof nonce "+nonce+" doesn't match the expected nonce")
+
+		accountProofVerifyFlags = flagsAccountProofVerify{}
+		_, err = verifyAccountProof([]string{"proof.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "app identifier must be provided")
+	})
+
+	t.Run("Generate and verify", func(t *testing.T) {
+		state.Accounts().AddOrUpdate(&accounts.Account{
+			Name:    "proof-signer",
+			Address: address,
+			Key:     &testKey{key: key0, sign: sign0},
+		})
+		accountProofGenerateFlags = flagsAccountProofGenerate{Signer: "proof-signer", AppID: appID, Nonce: nonce}
+
+		result, err := generateAccountProof(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		content, err := json.Marshal(result.JSON())
+		require.NoError(t, err)
+		require.NoError(t, rw.WriteFile("generated-proof.json", content, 0644))
+
+		accountProofVerifyFlags = flagsAccountProofVerify{AppID: appID, Nonce: nonce}
+		verification, err := verifyAccountProof([]string{"generated-proof.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.True(t, verification.(*accountProofVerificationResult).valid())
+
+		accountProofVerifyFlags = flagsAccountProofVerify{AppID: "OTHER-APP-ID"}
+		verification, err = verifyAccountProof([]string{"generated-proof.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.False(t, verification.(*accountProofVerificationResult).valid())
+	})
+
+	t.Run("Generate fail", func(t *testing.T) {
+		accountProofGenerateFlags = flagsAccountProofGenerate{Signer: "emulator-account", AppID: appID, Nonce: "aaaa"}
+		_, err := generateAccountProof(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid nonce, must be at least 32 bytes")
+
+		accountProofGenerateFlags = flagsAccountProofGenerate{Signer: "invalid", AppID: appID, Nonce: nonce}
+		_, err = generateAccountProof(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "could not find account with name invalid in the configuration")
+	})
+}
+
+func Test_DomainTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  []byte
+	}{
+		{name: "none", tag: nil},
+		{name: "", tag: nil},
+		{name: "user", tag: flowsdk.UserDomainTag[:]},
+		{name: "transaction", tag: flowsdk.TransactionDomainTag[:]},
+		{name: "custom:MY-APP-V1", tag: append([]byte("MY-APP-V1"), make([]byte, 23)...)},
+	}
+
+	for _, test := range tests {
+		tag, err := domainTag(test.name)
+		require.NoError(t, err)
+		assert.Equal(t, test.tag, tag)
+	}
+
+	_, err := domainTag("custom:")
+	assert.EqualError(t, err, "invalid domain tag custom:, valid values are: none, user, transaction or custom:<tag>")
+
+	_, err = domainTag("custom:THIS-TAG-IS-LONGER-THAN-32-CHARACTERS")
+	assert.EqualError(t, err, "domain tag THIS-TAG-IS-LONGER-THAN-32-CHARACTERS can not be longer than 32 characters")
+}
+
+func Test_SignatureDocument(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	key0, sign0 := testAccountKey(t, 0, 1000)
+	key1, sign1 := testAccountKey(t, 1, 1000)
+	key1.Revoked = true
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(&flowsdk.Account{
+		Address: flowsdk.HexToAddress("0x01"),
+		Keys:    []*flowsdk.AccountKey{key0, key1},
+	}, nil)
+
+	content := make([]byte, 1<<20)
+	_, _ = rand.Read(content)
+	filename := "release.tar.gz"
+	require.NoError(t, rw.WriteFile(filename, content, 0644))
+
+	tag, _ := domainTag("custom:RELEASE-V1")
+	signed := append(tag, content...)
+
+	writeDocument := func(t *testing.T, keyIndex uint32, signature string) {
+		document := signatureDocument{
+			Version:   signatureDocumentVersion,
+			File:      "release.tar.gz",
+			Address:   "0x0000000000000001",
+			KeyIndex:  keyIndex,
+			SigAlgo:   "ECDSA_P256",
+			HashAlgo:  "SHA3_256",
+			DomainTag: "custom:RELEASE-V1",
+			Signature: signature,
+		}
+		encoded, err := json.Marshal(document)
+		require.NoError(t, err)
+		require.NoError(t, rw.WriteFile("release.tar.gz.sig", encoded, 0644))
+	}
+
+	t.Cleanup(func() { verifyFlags = flagsVerify{} })
+
+	t.Run("Verify", func(t *testing.T) {
+		writeDocument(t, 0, sign0(signed))
+		verifyFlags = flagsVerify{Document: "release.tar.gz.sig"}
+
+		result, err := verify([]string{filename}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.True(t, result.(*verificationResult).valid)
+	})
+
+	t.Run("Verify modified file", func(t *testing.T) {
+		writeDocument(t, 0, sign0(append(tag, content[1:]...)))
+		verifyFlags = flagsVerify{Document: "release.tar.gz.sig"}
+
+		result, err := verify([]string{filename}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.False(t, result.(*verificationResult).valid)
+	})
+
+	t.Run("Verify revoked key", func(t *testing.T) {
+		writeDocument(t, 1, sign1(signed))
+		verifyFlags = flagsVerify{Document: "release.tar.gz.sig"}
+
+		result, err := verify([]string{filename}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.False(t, result.(*verificationResult).valid)
+	})
+
+	t.Run("Verify message with domain tag", func(t *testing.T) {
+		userTag, _ := domainTag(domainTagUser)
+		verifyFlags = flagsVerify{SigAlgo: "ECDSA_P256", HashAlgo: "SHA3_256", DomainTag: domainTagUser}
+
+		result, err := verify(
+			[]string{"test message", sign0(append(userTag, []byte("test message")...)), key0.PublicKey.String()},
+			command.GlobalFlags{},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+		require.NoError(t, err)
+		assert.True(t, result.(*verificationResult).valid)
+	})
+
+	t.Run("Generate and verify", func(t *testing.T) {
+		state.Accounts().AddOrUpdate(&accounts.Account{
+			Name:    "release-signer",
+			Address: flowsdk.HexToAddress("0x01"),
+			Key:     &testKey{key: key0, sign: sign0},
+		})
+		generateFlags = flagsGenerate{Signer: "release-signer", File: filename, DomainTag: "custom:RELEASE-V1"}
+		t.Cleanup(func() { generateFlags = flagsGenerate{} })
+
+		result, err := sign(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NoError(t, rw.WriteFile("generated.sig", []byte(result.String()), 0644))
+
+		verifyFlags = flagsVerify{Document: "generated.sig"}
+		verification, err := verify([]string{filename}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.True(t, verification.(*verificationResult).valid)
+
+		require.NoError(t, rw.WriteFile("modified.tar.gz", content[1:], 0644))
+		verification, err = verify([]string{"modified.tar.gz"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.False(t, verification.(*verificationResult).valid)
+	})
+
+	t.Run("Generate message public key", func(t *testing.T) {
+		generateFlags = flagsGenerate{Signer: "release-signer"}
+		t.Cleanup(func() { generateFlags = flagsGenerate{} })
+
+		result, err := sign([]string{"test message"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, key0.PublicKey.String(), result.(*signatureResult).pubKey())
+	})
+
+	t.Run("Generate fail", func(t *testing.T) {
+		generateFlags = flagsGenerate{Signer: "emulator-account", File: filename}
+		_, err := sign([]string{"test message"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "only use one, message argument or --file <filename>")
+
+		generateFlags = flagsGenerate{Signer: "emulator-account"}
+		_, err = sign(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "message argument is required")
+
+		generateFlags = flagsGenerate{Signer: "emulator-account", File: filename, DomainTag: "invalid"}
+		_, err = sign(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid domain tag invalid, valid values are: none, user, transaction or custom:<tag>")
+	})
+}
@@ -0,0 +1,276 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsVerifyAccount struct {
+	Sig       []string `default:"" flag:"sig" info:"Signature in the format keyIndex:signature, can be repeated for multiple keys"`
+	DomainTag string   `default:"user" flag:"domain-tag" info:"Domain tag prepended to the message before verifying: none, user, transaction or custom:<tag>"`
+}
+
+var verifyAccountFlags = flagsVerifyAccount{}
+
+var verifyAccountCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "verify-account <address> <message>",
+		Short:   "Verify the message signatures against the account keys",
+		Example: "flow signatures verify-account f8d6e0586b0a20c7 'The quick brown fox jumps over the lazy dog' --sig 0:99fa...25b --sig 1:af3...52d",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &verifyAccountFlags,
+	Run:   verifyAccount,
+}
+
+// signatureWeightThreshold is the combined key weight required for the account signatures to be valid.
+const signatureWeightThreshold = 1000
+
+type accountSignature struct {
+	keyIndex  uint32
+	signature []byte
+}
+
+// parseAccountSignature parses the signature in the keyIndex:signature format.
+func parseAccountSignature(value string) (*accountSignature, error) {
+	index, sig, found := strings.Cut(value, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid signature %s, expected format keyIndex:signature", value)
+	}
+
+	keyIndex, err := strconv.ParseUint(index, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key index %s: %w", index, err)
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(sig, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature for key index %d: %w", keyIndex, err)
+	}
+
+	return &accountSignature{keyIndex: uint32(keyIndex), signature: signature}, nil
+}
+
+func verifyAccount(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address := flowsdk.HexToAddress(args[0])
+	message := []byte(args[1])
+
+	if len(verifyAccountFlags.Sig) == 0 {
+		return nil, fmt.Errorf("at least one signature must be provided with the --sig flag")
+	}
+
+	tag, err := domainTag(verifyAccountFlags.DomainTag)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]*accountSignature, 0, len(verifyAccountFlags.Sig))
+	indexes := make(map[uint32]bool)
+	for _, value := range verifyAccountFlags.Sig {
+		sig, err := parseAccountSignature(value)
+		if err != nil {
+			return nil, err
+		}
+		if indexes[sig.keyIndex] {
+			return nil, fmt.Errorf("duplicate signature for key index %d", sig.keyIndex)
+		}
+		indexes[sig.keyIndex] = true
+		signatures = append(signatures, sig)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading account %s...", address))
+	account, err := flow.GetAccount(context.Background(), address)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	verifications, err := verifyAccountSignatures(account, append(tag, message...), signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountVerificationResult{
+		address:       address,
+		message:       message,
+		verifications: verifications,
+	}, nil
+}
+
+type keyVerification struct {
+	keyIndex uint32
+	weight   int
+	revoked  bool
+	valid    bool
+}
+
+// verifyAccountSignatures verifies each signature with the matching account key, signatures of revoked keys are not verified.
+func verifyAccountSignatures(
+	account *flowsdk.Account,
+	message []byte,
+	signatures []*accountSignature,
+) ([]keyVerification, error) {
+	verifications := make([]keyVerification, 0, len(signatures))
+
+	for _, sig := range signatures {
+		var key *flowsdk.AccountKey
+		for _, k := range account.Keys {
+			if k.Index == sig.keyIndex {
+				key = k
+				break
+			}
+		}
+		if key == nil {
+			return nil, fmt.Errorf("key index %d does not exist on account %s", sig.keyIndex, account.Address)
+		}
+
+		verification := keyVerification{
+			keyIndex: key.Index,
+			weight:   key.Weight,
+			revoked:  key.Revoked,
+		}
+
+		if !key.Revoked {
+			hasher, err := crypto.NewHasher(key.HashAlgo)
+			if err != nil {
+				return nil, fmt.Errorf("unsupported hash algorithm of key index %d: %w", key.Index, err)
+			}
+
+			// an invalid signature encoding is reported as an invalid signature
+			valid, err := key.PublicKey.Verify(sig.signature, message, hasher)
+			verification.valid = err == nil && valid
+		}
+
+		verifications = append(verifications, verification)
+	}
+
+	return verifications, nil
+}
+
+type accountVerificationResult struct {
+	address       flowsdk.Address
+	message       []byte
+	verifications []keyVerification
+}
+
+var _ command.ResultWithExitCode = &accountVerificationResult{}
+
+func (r *accountVerificationResult) weight() int {
+	weight := 0
+	for _, v := range r.verifications {
+		if v.valid {
+			weight += v.weight
+		}
+	}
+
+	return weight
+}
+
+func (r *accountVerificationResult) valid() bool {
+	return r.weight() >= signatureWeightThreshold
+}
+
+func (r *accountVerificationResult) JSON() any {
+	keys := make([]map[string]any, 0, len(r.verifications))
+	for _, v := range r.verifications {
+		keys = append(keys, map[string]any{
+			"index":   v.keyIndex,
+			"weight":  v.weight,
+			"revoked": v.revoked,
+			"valid":   v.valid,
+		})
+	}
+
+	return map[string]any{
+		"valid":     r.valid(),
+		"address":   r.address.Hex(),
+		"message":   string(r.message),
+		"weight":    r.weight(),
+		"threshold": signatureWeightThreshold,
+		"keys":      keys,
+	}
+}
+
+func (r *accountVerificationResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Valid \t %v\n", r.valid())
+	_, _ = fmt.Fprintf(writer, "Address \t 0x%s\n", r.address.Hex())
+	_, _ = fmt.Fprintf(writer, "Message \t %s\n", r.message)
+	r.writeKeys(writer)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+// writeKeys writes the combined weight and the verification result of each key.
+func (r *accountVerificationResult) writeKeys(writer io.Writer) {
+	_, _ = fmt.Fprintf(writer, "Weight \t %d/%d\n", r.weight(), signatureWeightThreshold)
+	_, _ = fmt.Fprintf(writer, "\nKey Index \t Weight \t Result\n")
+
+	for _, v := range r.verifications {
+		status := fmt.Sprintf("%s valid", output.OkEmoji())
+		if v.revoked {
+			status = fmt.Sprintf("%s revoked, ignored", output.StopEmoji())
+		} else if !v.valid {
+			status = fmt.Sprintf("%s invalid", output.ErrorEmoji())
+		}
+		_, _ = fmt.Fprintf(writer, "%d \t %d \t %s\n", v.keyIndex, v.weight, status)
+	}
+}
+
+// ExitCode is non-zero when the valid signatures don't reach the signature weight threshold.
+func (r *accountVerificationResult) ExitCode() int {
+	if !r.valid() {
+		return 1
+	}
+
+	return 0
+}
+
+func (r *accountVerificationResult) Oneliner() string {
+	return fmt.Sprintf(
+		"valid: %v, address: 0x%s, weight: %d, threshold: %d",
+		r.valid(), r.address.Hex(), r.weight(), signatureWeightThreshold,
+	)
+}
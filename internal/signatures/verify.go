@@ -20,10 +20,13 @@ package signatures
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
 
@@ -35,18 +38,25 @@ import (
 )
 
 type flagsVerify struct {
-	SigAlgo  string `flag:"sig-algo" default:"ECDSA_P256" info:"Signature algorithm used to create the public key"`
-	HashAlgo string `flag:"hash-algo" default:"SHA3_256" info:"Hashing algorithm used to create signature"`
+	SigAlgo   string `flag:"sig-algo" default:"ECDSA_P256" info:"Signature algorithm used to create the public key"`
+	HashAlgo  string `flag:"hash-algo" default:"SHA3_256" info:"Hashing algorithm used to create signature"`
+	DomainTag string `flag:"domain-tag" default:"none" info:"Domain tag prepended to the message: none, user, transaction or custom:<tag>"`
+	Document  string `flag:"document" default:"" info:"Detached signature document of the file, the signature is verified against the on-chain account key"`
 }
 
 var verifyFlags = flagsVerify{}
 
 var verifyCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "verify <message> <signature> <public key>",
+		Use:     "verify [<message> <signature> <public key> | <filename> --document <signature document>]",
 		Short:   "Verify the signature",
-		Example: "flow signatures verify 'The quick brown fox jumps over the lazy dog' 99fa...25b af3...52d",
-		Args:    cobra.ExactArgs(3),
+		Example: "flow signatures verify 'The quick brown fox jumps over the lazy dog' 99fa...25b af3...52d\nflow signatures verify ./release.tar.gz --document release.tar.gz.sig",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if verifyFlags.Document != "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(3)(cmd, args)
+		},
 	},
 	Flags: &verifyFlags,
 	RunS:  verify,
@@ -55,10 +65,14 @@ var verifyCommand = &command.Command{
 func verify(
 	args []string,
 	_ command.GlobalFlags,
-	_ output.Logger,
-	_ flowkit.Services,
-	_ *flowkit.State,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
 ) (command.Result, error) {
+	if verifyFlags.Document != "" {
+		return verifyDocument(args[0], verifyFlags.Document, logger, flow, state)
+	}
+
 	message := []byte(args[0])
 
 	sig, err := hex.DecodeString(strings.ReplaceAll(args[1], "0x", ""))
@@ -84,7 +98,12 @@ func verify(
 		return nil, err
 	}
 
-	valid, err := pkey.Verify(sig, message, hasher)
+	tag, err := domainTag(verifyFlags.DomainTag)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := pkey.Verify(sig, append(tag, message...), hasher)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +118,86 @@ func verify(
 	}, nil
 }
 
+// verifyDocument verifies the file with the detached signature document using the on-chain account key.
+func verifyDocument(
+	filename string,
+	documentFilename string,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	content, err := state.ReadFile(documentFilename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature document from %s: %w", documentFilename, err)
+	}
+
+	var document signatureDocument
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("invalid signature document: %w", err)
+	}
+	if document.Version != signatureDocumentVersion {
+		return nil, fmt.Errorf("unsupported signature document version %d", document.Version)
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(document.Signature, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid document signature: %w", err)
+	}
+
+	tag, err := domainTag(document.DomainTag)
+	if err != nil {
+		return nil, err
+	}
+
+	address := flowsdk.HexToAddress(document.Address)
+	logger.StartProgress(fmt.Sprintf("Loading account %s...", address))
+	account, err := flow.GetAccount(context.Background(), address)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	var key *flowsdk.AccountKey
+	for _, k := range account.Keys {
+		if k.Index == document.KeyIndex {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("key index %d does not exist on account %s", document.KeyIndex, address)
+	}
+	if key.SigAlgo.String() != document.SigAlgo || key.HashAlgo.String() != document.HashAlgo {
+		return nil, fmt.Errorf(
+			"document algorithms %s and %s don't match the account key algorithms %s and %s",
+			document.SigAlgo, document.HashAlgo, key.SigAlgo, key.HashAlgo,
+		)
+	}
+
+	hasher, err := hashFile(state.ReaderWriter(), filename, tag, key.HashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	// signatures of revoked keys are never valid
+	valid := false
+	if !key.Revoked {
+		valid, err = key.PublicKey.Verify(sig, nil, hasher)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &verificationResult{
+		valid:     valid,
+		message:   []byte(filename),
+		signature: sig,
+		hashAlgo:  key.HashAlgo,
+		sigAlgo:   key.SigAlgo,
+		pubKey:    key.PublicKey.Encode(),
+	}, nil
+}
+
 type verificationResult struct {
 	valid     bool
 	message   []byte
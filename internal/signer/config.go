@@ -0,0 +1,369 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package signer implements the account key types the CLI supports in addition to the flowkit ones.
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/config"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// customKeyTypes are the key types that can be used in the configuration.
+var customKeyTypes = map[config.KeyType]bool{
+	KeyTypeKeystore: true,
+	KeyTypePKCS11:   true,
+	KeyTypeExternal: true,
+}
+
+// configKey is an account key of this package as stored in the configuration,
+// the fields are in the order flowkit writes the keys it supports.
+type configKey struct {
+	Type       config.KeyType `json:"type"`
+	Index      uint32         `json:"index,omitempty"`
+	SigAlgo    string         `json:"signatureAlgorithm,omitempty"`
+	HashAlgo   string         `json:"hashAlgorithm,omitempty"`
+	ResourceID string         `json:"resourceID,omitempty"`
+	Location   string         `json:"location,omitempty"`
+}
+
+type configAccount struct {
+	Address string    `json:"address"`
+	Key     configKey `json:"key"`
+}
+
+// Load loads the configuration like flowkit.Load, resolving the accounts using the key types of this package.
+//
+// flowkit only parses and serializes its own key types, so the reader writer of the state replaces the keys
+// of this package with file keys when the configuration files are read, and writes them from the accounts
+// of the state when the configuration is saved.
+func Load(configFilePaths []string, rw flowkit.ReaderWriter) (*flowkit.State, error) {
+	configRW := newReaderWriter(rw, configFilePaths)
+	state, err := flowkit.Load(configFilePaths, configRW)
+	if err != nil {
+		return nil, err
+	}
+	configRW.state = state
+
+	if err := configRW.resolveKeys(); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// Close releases the resources held by the account keys, like open token sessions.
+func Close(state *flowkit.State) error {
+	if state == nil {
+		return nil
+	}
+
+	for _, account := range *state.Accounts() {
+		if closer, ok := account.Key.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				return fmt.Errorf("failed to close key of account %s: %w", account.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+type readerWriter struct {
+	flowkit.ReaderWriter
+	// paths are the configuration files read by flowkit.Load.
+	paths map[string]bool
+	// keys are the keys of this package read from the configuration files by account name.
+	keys  map[string]configKey
+	state *flowkit.State
+}
+
+func newReaderWriter(rw flowkit.ReaderWriter, configFilePaths []string) *readerWriter {
+	if wrapped, ok := rw.(*readerWriter); ok {
+		rw = wrapped.ReaderWriter
+	}
+
+	paths := map[string]bool{
+		config.DefaultPath:  true,
+		config.GlobalPath(): true,
+	}
+	for _, path := range configFilePaths {
+		paths[filepath.Clean(path)] = true
+	}
+
+	return &readerWriter{
+		ReaderWriter: rw,
+		paths:        paths,
+		keys:         make(map[string]configKey),
+	}
+}
+
+func (rw *readerWriter) ReadFile(source string) ([]byte, error) {
+	data, err := rw.ReaderWriter.ReadFile(source)
+	if err != nil || !rw.paths[filepath.Clean(source)] {
+		return data, err
+	}
+
+	return rw.readKeys(data), nil
+}
+
+func (rw *readerWriter) WriteFile(filename string, data []byte, perm os.FileMode) error {
+	if rw.state != nil && filepath.Ext(filename) == ".json" {
+		var err error
+		data, err = rw.writeKeys(data)
+		if err != nil {
+			return fmt.Errorf("failed to write account keys: %w", err)
+		}
+	}
+
+	return rw.ReaderWriter.WriteFile(filename, data, perm)
+}
+
+// Open opens the file for streaming when the wrapped reader writer supports it, see util.OpenFile.
+func (rw *readerWriter) Open(name string) (afero.File, error) {
+	opener, ok := rw.ReaderWriter.(util.FileOpener)
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: errors.ErrUnsupported}
+	}
+
+	return opener.Open(name)
+}
+
+// readKeys keeps the account keys using the key types of this package and replaces them with file keys,
+// so flowkit parses the accounts. The data is returned unchanged if it doesn't contain such keys.
+func (rw *readerWriter) readKeys(data []byte) []byte {
+	var conf map[string]json.RawMessage
+	if err := json.Unmarshal(data, &conf); err != nil || conf["accounts"] == nil {
+		return data
+	}
+
+	var accounts map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(conf["accounts"], &accounts); err != nil {
+		return data
+	}
+
+	changed := false
+	for name, account := range accounts {
+		// accounts of a later configuration file replace the ones of the previous files
+		delete(rw.keys, name)
+
+		var key configKey
+		if err := json.Unmarshal(account["key"], &key); err != nil || !customKeyTypes[key.Type] {
+			continue // simple accounts have a hex key
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(account["key"], &fields); err != nil {
+			continue
+		}
+
+		// flowkit parses the index and algorithms of the file key, the location is replaced once loaded
+		fields["type"], _ = json.Marshal(config.KeyTypeFile)
+		fields["location"], _ = json.Marshal(name)
+		delete(fields, "resourceID")
+
+		encoded, err := json.Marshal(fields)
+		if err != nil {
+			return data
+		}
+		account["key"] = encoded
+		rw.keys[name] = key
+		changed = true
+	}
+
+	if !changed {
+		return data
+	}
+
+	encoded, err := json.Marshal(accounts)
+	if err != nil {
+		return data
+	}
+	conf["accounts"] = encoded
+
+	encoded, err = json.Marshal(conf)
+	if err != nil {
+		return data
+	}
+
+	return encoded
+}
+
+// resolveKeys replaces the file keys of the accounts read with the keys of this package.
+func (rw *readerWriter) resolveKeys() error {
+	for i := range *rw.state.Accounts() {
+		account := &(*rw.state.Accounts())[i]
+		key, ok := rw.keys[account.Name]
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch key.Type {
+		case KeyTypeKeystore:
+			account.Key, err = NewKeystoreKey(
+				key.ResourceID,
+				key.Location,
+				account.Key.Index(),
+				account.Key.SigAlgo(),
+				account.Key.HashAlgo(),
+				rw,
+			)
+		case KeyTypePKCS11:
+			account.Key, err = NewPKCS11Key(
+				key.ResourceID,
+				account.Key.Index(),
+				account.Key.SigAlgo(),
+				account.Key.HashAlgo(),
+			)
+		case KeyTypeExternal:
+			account.Key, err = NewExternalKey(
+				key.ResourceID,
+				account.Key.Index(),
+				account.Key.SigAlgo(),
+				account.Key.HashAlgo(),
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid key of account %s: %w", account.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeKeys writes the resource ID and location of the account keys using the key types of this package,
+// which flowkit leaves out when serializing the configuration. The data is returned unchanged if it
+// doesn't contain such keys.
+func (rw *readerWriter) writeKeys(data []byte) ([]byte, error) {
+	var conf struct {
+		Accounts map[string]json.RawMessage `json:"accounts"`
+	}
+	if err := json.Unmarshal(data, &conf); err != nil || conf.Accounts == nil {
+		return data, nil
+	}
+
+	changed := false
+	for name, encoded := range conf.Accounts {
+		var written struct {
+			Key configKey `json:"key"`
+		}
+		if err := json.Unmarshal(encoded, &written); err != nil || !customKeyTypes[written.Key.Type] {
+			continue
+		}
+
+		account, err := rw.state.Accounts().ByName(name)
+		if err != nil || account.Key.Type() != written.Key.Type {
+			continue
+		}
+
+		keyConfig := account.Key.ToConfig()
+		written.Key.ResourceID = keyConfig.ResourceID
+		written.Key.Location = filepath.ToSlash(keyConfig.Location)
+
+		conf.Accounts[name], err = marshal(configAccount{
+			Address: account.Address.String(),
+			Key:     written.Key,
+		})
+		if err != nil {
+			return nil, err
+		}
+		changed = true
+	}
+
+	if !changed {
+		return data, nil
+	}
+
+	accounts, err := marshal(conf.Accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return replaceField(data, "accounts", accounts)
+}
+
+// marshal encodes the value without escaping HTML characters, so URIs stay readable.
+func marshal(value any) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(b.Bytes()), nil
+}
+
+// replaceField replaces the value of a field of the JSON object keeping the order of the fields,
+// the object is indented like flowkit indents the configuration.
+func replaceField(data []byte, name string, value json.RawMessage) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("invalid configuration object")
+	}
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		field, _ := token.(string)
+
+		var fieldValue json.RawMessage
+		if err := decoder.Decode(&fieldValue); err != nil {
+			return nil, err
+		}
+		if field == name {
+			fieldValue = value
+		}
+
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		encoded, err := marshal(field)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(encoded)
+		b.WriteByte(':')
+		b.Write(fieldValue)
+	}
+	b.WriteByte('}')
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, b.Bytes(), "", "\t"); err != nil {
+		return nil, err
+	}
+
+	return indented.Bytes(), nil
+}
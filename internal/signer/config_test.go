@@ -0,0 +1,221 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flowkit/v2/config"
+)
+
+const testConfig = `{
+	"accounts": {
+		"alice": {
+			"address": "f8d6e0586b0a20c7",
+			"key": {
+				"type": "keystore",
+				"index": 1,
+				"signatureAlgorithm": "ECDSA_P256",
+				"hashAlgorithm": "SHA3_256",
+				"resourceID": "alice",
+				"location": "keys/keystore.json"
+			}
+		},
+		"bob": {
+			"address": "179b6b1cb6755e31",
+			"key": "cf3178b20a73846dc8bf6255c79be47178b0744dd8244bcff099e449a9700d7f"
+		}
+	}
+}`
+
+func Test_Load(t *testing.T) {
+	rw := &afero.Afero{Fs: afero.NewMemMapFs()}
+	require.NoError(t, rw.WriteFile("flow.json", []byte(testConfig), 0644))
+
+	t.Run("Resolve keys", func(t *testing.T) {
+		state, err := Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		require.IsType(t, &KeystoreKey{}, alice.Key)
+		assert.Equal(t, KeyTypeKeystore, alice.Key.Type())
+		assert.Equal(t, "alice", alice.Key.(*KeystoreKey).Name())
+		assert.Equal(t, uint32(1), alice.Key.Index())
+		assert.Equal(t, crypto.ECDSA_P256, alice.Key.SigAlgo())
+
+		bob, err := state.Accounts().ByName("bob")
+		require.NoError(t, err)
+		assert.Equal(t, config.KeyTypeHex, bob.Key.Type())
+	})
+
+	t.Run("Save keys", func(t *testing.T) {
+		state, err := Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+		require.NoError(t, state.Save("saved.json"))
+
+		saved, err := rw.ReadFile("saved.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(saved), `"key": {
+				"type": "keystore",
+				"index": 1,
+				"resourceID": "alice",
+				"location": "keys/keystore.json"
+			}`)
+		assert.NotContains(t, string(saved), `"type": "file"`)
+
+		reloaded, err := Load([]string{"saved.json"}, rw)
+		require.NoError(t, err)
+		alice, err := reloaded.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, KeyTypeKeystore, alice.Key.Type())
+	})
+
+	t.Run("PKCS#11 keys", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("pkcs11.json", []byte(`{
+			"accounts": {
+				"alice": {
+					"address": "f8d6e0586b0a20c7",
+					"key": {
+						"type": "pkcs11",
+						"resourceID": "pkcs11:slot-id=0;object=flow-key?module-path=/usr/lib/softhsm/libsofthsm2.so&pin-value=1234"
+					}
+				}
+			}
+		}`), 0644))
+
+		state, err := Load([]string{"pkcs11.json"}, rw)
+		require.NoError(t, err)
+
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		require.IsType(t, &PKCS11Key{}, alice.Key)
+		assert.Equal(t, "flow-key", alice.Key.(*PKCS11Key).Label())
+		assert.Equal(t, "1234", alice.Key.(*PKCS11Key).pin)
+
+		require.NoError(t, state.Save("pkcs11-saved.json"))
+		saved, err := rw.ReadFile("pkcs11-saved.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(saved), `"key": {
+				"type": "pkcs11",
+				"resourceID": "pkcs11:slot-id=0;object=flow-key?module-path=/usr/lib/softhsm/libsofthsm2.so"
+			}`)
+		assert.NotContains(t, string(saved), "pin-value")
+	})
+
+	t.Run("External keys", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("external.json", []byte(`{
+			"accounts": {
+				"alice": {
+					"address": "f8d6e0586b0a20c7",
+					"key": {
+						"type": "external",
+						"index": 2,
+						"resourceID": "flow-signer --profile alice"
+					}
+				}
+			}
+		}`), 0644))
+
+		state, err := Load([]string{"external.json"}, rw)
+		require.NoError(t, err)
+
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		require.IsType(t, &ExternalKey{}, alice.Key)
+		assert.Equal(t, "flow-signer --profile alice", alice.Key.(*ExternalKey).command)
+		assert.Equal(t, uint32(2), alice.Key.Index())
+
+		require.NoError(t, state.Save("external-saved.json"))
+		saved, err := rw.ReadFile("external-saved.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(saved), `"key": {
+				"type": "external",
+				"index": 2,
+				"resourceID": "flow-signer --profile alice"
+			}`)
+	})
+
+	t.Run("Keep field order", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("networks.json", []byte(`{
+			"networks": {"emulator": "127.0.0.1:3569"},
+			"accounts": {
+				"alice": {
+					"address": "f8d6e0586b0a20c7",
+					"key": {"type": "external", "resourceID": "flow-signer"}
+				}
+			}
+		}`), 0644))
+
+		state, err := Load([]string{"networks.json"}, rw)
+		require.NoError(t, err)
+		require.NoError(t, state.Save("networks.json"))
+
+		saved, err := rw.ReadFile("networks.json")
+		require.NoError(t, err)
+		assert.Equal(t, `{
+	"networks": {
+		"emulator": "127.0.0.1:3569"
+	},
+	"accounts": {
+		"alice": {
+			"address": "f8d6e0586b0a20c7",
+			"key": {
+				"type": "external",
+				"resourceID": "flow-signer"
+			}
+		}
+	}
+}`, string(saved))
+	})
+
+	t.Run("Override keys", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("override.json", []byte(`{
+			"accounts": {
+				"alice": {
+					"address": "f8d6e0586b0a20c7",
+					"key": "cf3178b20a73846dc8bf6255c79be47178b0744dd8244bcff099e449a9700d7f"
+				}
+			}
+		}`), 0644))
+
+		state, err := Load([]string{"flow.json", "override.json"}, rw)
+		require.NoError(t, err)
+
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, config.KeyTypeHex, alice.Key.Type())
+	})
+
+	t.Run("Unchanged files", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("accounts.json", []byte(testConfig), 0644))
+
+		state, err := Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+
+		data, err := state.ReaderWriter().ReadFile("accounts.json")
+		require.NoError(t, err)
+		assert.Equal(t, testConfig, string(data))
+	})
+}
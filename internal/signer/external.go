@@ -0,0 +1,310 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/config"
+)
+
+// KeyTypeExternal is the key type of keys signing with an external executable.
+const KeyTypeExternal config.KeyType = "external"
+
+// ProtocolVersion is the version of the request sent to the executable.
+const ProtocolVersion = 1
+
+const (
+	ActionSign      = "sign"
+	ActionPublicKey = "publicKey"
+)
+
+// Request is written to the executable stdin.
+type Request struct {
+	Version  int    `json:"version"`
+	Action   string `json:"action"`
+	Message  string `json:"message,omitempty"`
+	SigAlgo  string `json:"sigAlgo"`
+	HashAlgo string `json:"hashAlgo"`
+	KeyIndex uint32 `json:"keyIndex"`
+}
+
+// Response is read from the executable stdout.
+type Response struct {
+	Signature string `json:"signature,omitempty"`
+	PublicKey string `json:"publicKey,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+var _ accounts.Key = &ExternalKey{}
+
+// ExternalKey implements signing by invoking an external executable.
+//
+// The executable is started for every request, it receives a single JSON request on stdin
+// and must write a single JSON response to stdout:
+//
+//	request:  {"version":1,"action":"sign","message":"<hex>","sigAlgo":"ECDSA_P256","hashAlgo":"SHA3_256","keyIndex":0}
+//	response: {"signature":"<hex>"}
+//
+// The message is the full message to be signed, the executable hashes it with the hash algorithm
+// and returns the signature as r || s. Signing only requires the "sign" action, the optional "publicKey"
+// action returns {"publicKey":"<hex>"} instead and is used to check the signer and show its public key.
+// Failures are reported with {"error":"<message>"} or a non-zero exit code.
+type ExternalKey struct {
+	index    uint32
+	sigAlgo  crypto.SignatureAlgorithm
+	hashAlgo crypto.HashAlgorithm
+	command  string
+	args     []string
+}
+
+// NewExternalKey creates a key signing with the command.
+//
+// The command is split into arguments like a shell does: arguments are separated by spaces, and spaces
+// are kept in single or double quotes or when escaped with a backslash, e.g. "'/opt/My Signer/sign' --key flow".
+func NewExternalKey(
+	command string,
+	index uint32,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*ExternalKey, error) {
+	args, err := splitCommand(command)
+	if err != nil {
+		return nil, fmt.Errorf("invalid external signer command: %w", err)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("external signer command is required")
+	}
+
+	return &ExternalKey{
+		index:    index,
+		sigAlgo:  sigAlgo,
+		hashAlgo: hashAlgo,
+		command:  command,
+		args:     args,
+	}, nil
+}
+
+// splitCommand splits the command into arguments with the quoting rules of a POSIX shell,
+// without expanding variables or other special characters.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+
+	for i := 0; i < len(command); i++ {
+		switch c := command[i]; c {
+		case ' ', '\t', '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		case '\\':
+			inArg = true
+			if i++; i < len(command) {
+				arg.WriteByte(command[i])
+			}
+		case '\'':
+			inArg = true
+			end := strings.IndexByte(command[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			arg.WriteString(command[i+1 : i+1+end])
+			i += end + 1
+		case '"':
+			inArg = true
+			for i++; ; i++ {
+				if i >= len(command) {
+					return nil, fmt.Errorf("unterminated double quote")
+				}
+				if command[i] == '"' {
+					break
+				}
+				// in double quotes a backslash only escapes these characters
+				if command[i] == '\\' && i+1 < len(command) && strings.IndexByte(`"\\$`+"`", command[i+1]) >= 0 {
+					i++
+				}
+				arg.WriteByte(command[i])
+			}
+		default:
+			inArg = true
+			arg.WriteByte(c)
+		}
+	}
+
+	if inArg {
+		args = append(args, arg.String())
+	}
+
+	return args, nil
+}
+
+func (k *ExternalKey) Type() config.KeyType {
+	return KeyTypeExternal
+}
+
+func (k *ExternalKey) Index() uint32 {
+	return k.index
+}
+
+func (k *ExternalKey) SigAlgo() crypto.SignatureAlgorithm {
+	return k.sigAlgo
+}
+
+func (k *ExternalKey) HashAlgo() crypto.HashAlgorithm {
+	return k.hashAlgo
+}
+
+// ToConfig converts the key to configuration, the command is stored as the resource ID.
+func (k *ExternalKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:       KeyTypeExternal,
+		Index:      k.index,
+		SigAlgo:    k.sigAlgo,
+		HashAlgo:   k.hashAlgo,
+		ResourceID: k.command,
+	}
+}
+
+func (k *ExternalKey) Validate() error {
+	executable := k.args[0]
+	if _, err := exec.LookPath(executable); err != nil {
+		return fmt.Errorf("external signer %s not found: %w", executable, err)
+	}
+
+	return nil
+}
+
+func (k *ExternalKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible")
+}
+
+// Signer checks the executable exists, the public key is only requested from it when used.
+func (k *ExternalKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &externalSigner{ctx: ctx, key: k}, nil
+}
+
+// call runs the executable with the request and decodes its response.
+func (k *ExternalKey) call(ctx context.Context, request Request) (*Response, error) {
+	request.Version = ProtocolVersion
+	request.SigAlgo = k.sigAlgo.String()
+	request.HashAlgo = k.hashAlgo.String()
+	request.KeyIndex = k.index
+
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, k.args[0], k.args[1:]...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+
+	var response Response
+	decodeErr := json.Unmarshal(stdout.Bytes(), &response)
+	if decodeErr == nil && response.Error != "" {
+		return nil, fmt.Errorf("external signer failed: %s", response.Error)
+	}
+	if runErr != nil {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("external signer failed: %w: %s", runErr, strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("external signer failed: %w", runErr)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("invalid external signer response: %w", decodeErr)
+	}
+
+	return &response, nil
+}
+
+// PublicKey requests the public key from the executable.
+func (k *ExternalKey) PublicKey(ctx context.Context) (crypto.PublicKey, error) {
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+
+	response, err := k.call(ctx, Request{Action: ActionPublicKey})
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := crypto.DecodePublicKeyHex(k.sigAlgo, strings.TrimPrefix(response.PublicKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid external signer public key: %w", err)
+	}
+
+	return publicKey, nil
+}
+
+type externalSigner struct {
+	ctx       context.Context
+	key       *ExternalKey
+	publicKey crypto.PublicKey
+}
+
+func (s *externalSigner) Sign(message []byte) ([]byte, error) {
+	response, err := s.key.call(s.ctx, Request{
+		Action:  ActionSign,
+		Message: hex.EncodeToString(message),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(response.Signature, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid external signer signature: %w", err)
+	}
+	if len(signature) == 0 {
+		return nil, fmt.Errorf("external signer returned an empty signature")
+	}
+
+	return signature, nil
+}
+
+// PublicKey requests the public key from the executable the first time it's used,
+// nil is returned if the executable doesn't support the public key action.
+func (s *externalSigner) PublicKey() crypto.PublicKey {
+	if s.publicKey == nil {
+		s.publicKey, _ = s.key.PublicKey(s.ctx)
+	}
+
+	return s.publicKey
+}
@@ -0,0 +1,236 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+const helperEnv = "FLOW_TEST_EXTERNAL_SIGNER"
+
+// TestMain runs the test binary as an external signer when the helper environment variable is set.
+func TestMain(m *testing.M) {
+	if mode, ok := os.LookupEnv(helperEnv); ok {
+		os.Exit(runHelperSigner(mode))
+	}
+
+	os.Exit(m.Run())
+}
+
+func runHelperSigner(mode string) int {
+	var request Request
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if mode == "error" {
+		_ = json.NewEncoder(os.Stdout).Encode(Response{Error: "request rejected"})
+		return 1
+	}
+
+	d, _ := new(big.Int).SetString(testPrivateKey, 16)
+	key := &ecdsa.PrivateKey{D: d, PublicKey: ecdsa.PublicKey{Curve: elliptic.P256()}}
+	key.PublicKey.X, key.PublicKey.Y = elliptic.P256().ScalarBaseMult(d.Bytes())
+
+	if request.Action == ActionPublicKey {
+		if mode == "sign-only" {
+			_ = json.NewEncoder(os.Stdout).Encode(Response{Error: "unsupported action"})
+			return 1
+		}
+
+		publicKey := append(key.PublicKey.X.FillBytes(make([]byte, 32)), key.PublicKey.Y.FillBytes(make([]byte, 32))...)
+		if mode == "invalid-public-key" {
+			publicKey = publicKey[:10]
+		}
+		_ = json.NewEncoder(os.Stdout).Encode(Response{PublicKey: hex.EncodeToString(publicKey)})
+		return 0
+	}
+
+	switch mode {
+	case "crash":
+		_, _ = fmt.Fprintln(os.Stderr, "signer crashed")
+		return 2
+	case "echo":
+		_ = json.NewEncoder(os.Stdout).Encode(Response{
+			Signature: fmt.Sprintf("%x", []byte(fmt.Sprintf("%s|%s|%s|%d", request.Action, request.SigAlgo, request.HashAlgo, request.KeyIndex))),
+		})
+		return 0
+	}
+
+	message, _ := hex.DecodeString(request.Message)
+	digest := sha3.Sum256(message)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	_ = json.NewEncoder(os.Stdout).Encode(Response{Signature: hex.EncodeToString(signature)})
+	return 0
+}
+
+func Test_ExternalKey(t *testing.T) {
+	newKey := func(t *testing.T, mode string) *ExternalKey {
+		t.Setenv(helperEnv, mode)
+		key, err := NewExternalKey(os.Args[0], 3, crypto.ECDSA_P256, crypto.SHA3_256)
+		require.NoError(t, err)
+		return key
+	}
+
+	t.Run("Sign", func(t *testing.T) {
+		key := newKey(t, "sign")
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+
+		message := []byte("flow")
+		signature, err := signer.Sign(message)
+		require.NoError(t, err)
+
+		expected, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, testPrivateKey)
+		require.NoError(t, err)
+		require.NotNil(t, signer.PublicKey())
+		assert.True(t, expected.PublicKey().Equals(signer.PublicKey()))
+
+		hasher, err := crypto.NewHasher(crypto.SHA3_256)
+		require.NoError(t, err)
+		valid, err := signer.PublicKey().Verify(signature, message, hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Sign without public key", func(t *testing.T) {
+		key := newKey(t, "sign-only")
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+
+		signature, err := signer.Sign([]byte("flow"))
+		require.NoError(t, err)
+		assert.Len(t, signature, 64)
+		assert.Nil(t, signer.PublicKey())
+
+		_, err = key.PublicKey(context.Background())
+		assert.EqualError(t, err, "external signer failed: unsupported action")
+	})
+
+	t.Run("Command with spaces", func(t *testing.T) {
+		executable, err := os.ReadFile(os.Args[0])
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "flow signer")
+		require.NoError(t, os.WriteFile(path, executable, 0755))
+
+		t.Setenv(helperEnv, "sign")
+		key, err := NewExternalKey(fmt.Sprintf("'%s' -test.run=none", path), 0, crypto.ECDSA_P256, crypto.SHA3_256)
+		require.NoError(t, err)
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+		_, err = signer.Sign([]byte("flow"))
+		require.NoError(t, err)
+	})
+
+	t.Run("Request", func(t *testing.T) {
+		key := newKey(t, "echo")
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+
+		signature, err := signer.Sign([]byte("flow"))
+		require.NoError(t, err)
+		assert.Equal(t, "sign|ECDSA_P256|SHA3_256|3", string(signature))
+	})
+
+	t.Run("Fail signer error", func(t *testing.T) {
+		signer, err := newKey(t, "error").Signer(context.Background())
+		require.NoError(t, err)
+
+		_, err = signer.Sign([]byte("flow"))
+		assert.EqualError(t, err, "external signer failed: request rejected")
+	})
+
+	t.Run("Fail invalid public key", func(t *testing.T) {
+		key := newKey(t, "invalid-public-key")
+		_, err := key.PublicKey(context.Background())
+		assert.ErrorContains(t, err, "invalid external signer public key")
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, signer.PublicKey())
+	})
+
+	t.Run("Fail signer exit", func(t *testing.T) {
+		signer, err := newKey(t, "crash").Signer(context.Background())
+		require.NoError(t, err)
+
+		_, err = signer.Sign([]byte("flow"))
+		assert.EqualError(t, err, "external signer failed: exit status 2: signer crashed")
+	})
+
+	t.Run("Fail missing executable", func(t *testing.T) {
+		key, err := NewExternalKey("flow-missing-signer --vault", 0, crypto.ECDSA_P256, crypto.SHA3_256)
+		require.NoError(t, err)
+
+		_, err = key.Signer(context.Background())
+		assert.ErrorContains(t, err, "external signer flow-missing-signer not found")
+
+		_, err = NewExternalKey(" ", 0, crypto.ECDSA_P256, crypto.SHA3_256)
+		assert.EqualError(t, err, "external signer command is required")
+
+		_, err = NewExternalKey("'flow signer", 0, crypto.ECDSA_P256, crypto.SHA3_256)
+		assert.EqualError(t, err, "invalid external signer command: unterminated single quote")
+	})
+}
+
+func Test_SplitCommand(t *testing.T) {
+	tests := map[string][]string{
+		"flow-signer":                         {"flow-signer"},
+		"  flow-signer   --key  flow ":        {"flow-signer", "--key", "flow"},
+		"'/opt/Flow Signer/sign' --key flow":  {"/opt/Flow Signer/sign", "--key", "flow"},
+		`"/opt/Flow Signer/sign" --key "a b"`: {"/opt/Flow Signer/sign", "--key", "a b"},
+		`/opt/Flow\ Signer/sign`:              {"/opt/Flow Signer/sign"},
+		`'C:\Program Files\sign.exe'`:         {`C:\Program Files\sign.exe`},
+		`sign --label "say \"hi\" \n"`:        {"sign", "--label", `say "hi" \n`},
+		`sign --empty ''`:                     {"sign", "--empty", ""},
+		`sign --key=a'b c'd`:                  {"sign", "--key=ab cd"},
+	}
+
+	for command, expected := range tests {
+		args, err := splitCommand(command)
+		require.NoError(t, err)
+		assert.Equal(t, expected, args, command)
+	}
+
+	_, err := splitCommand(`sign "flow`)
+	assert.EqualError(t, err, "unterminated double quote")
+}
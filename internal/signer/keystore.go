@@ -0,0 +1,422 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/config"
+
+	"github.com/onflow/flow-cli/internal/prompt"
+	"github.com/onflow/flow-cli/internal/settings"
+)
+
+// KeyTypeKeystore is the key type of keys stored in the encrypted keystore.
+const KeyTypeKeystore config.KeyType = "keystore"
+
+// KeystorePassphraseEnv is the environment variable the keystore passphrase is read from
+// before falling back to an interactive prompt.
+const KeystorePassphraseEnv = "FLOW_KEYSTORE_PASSPHRASE"
+
+const (
+	keystoreVersion = 1
+	keystoreKDF     = "scrypt"
+	keystoreCipher  = "aes-256-gcm"
+
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLength   = 32
+)
+
+// Keystore is the content of the keystore file, private keys are only ever stored encrypted.
+type Keystore struct {
+	Version int                      `json:"version"`
+	Keys    map[string]KeystoreEntry `json:"keys"`
+}
+
+// KeystoreEntry is an encrypted key, the algorithms and the public key are stored in plaintext.
+type KeystoreEntry struct {
+	SigAlgo   string         `json:"sigAlgo"`
+	HashAlgo  string         `json:"hashAlgo"`
+	PublicKey string         `json:"publicKey"`
+	Crypto    keystoreCrypto `json:"crypto"`
+}
+
+type keystoreCrypto struct {
+	KDF        string          `json:"kdf"`
+	KDFParams  keystoreKDFArgs `json:"kdfParams"`
+	Cipher     string          `json:"cipher"`
+	Nonce      string          `json:"nonce"`
+	Ciphertext string          `json:"ciphertext"`
+}
+
+type keystoreKDFArgs struct {
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	KeyLen int    `json:"keyLen"`
+	Salt   string `json:"salt"`
+}
+
+// KeystorePath returns the keystore location, defaulting to the CLI settings directory.
+func KeystorePath(path string) string {
+	if path != "" {
+		return path
+	}
+
+	return filepath.Join(settings.FileDir(), "keystore.json")
+}
+
+// LoadKeystore reads the keystore from the path, a missing file results in an empty keystore.
+func LoadKeystore(rw flowkit.ReaderWriter, path string) (*Keystore, error) {
+	data, err := rw.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Keystore{Version: keystoreVersion, Keys: make(map[string]KeystoreEntry)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore %s: %w", path, err)
+	}
+
+	var ks Keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore %s: %w", path, err)
+	}
+	if ks.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	if ks.Keys == nil {
+		ks.Keys = make(map[string]KeystoreEntry)
+	}
+
+	return &ks, nil
+}
+
+// Save writes the keystore to the path, readable only by the current user.
+func (k *Keystore) Save(rw flowkit.ReaderWriter, path string) error {
+	data, err := json.MarshalIndent(k, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := rw.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+
+	if err := rw.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write keystore %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Names returns the sorted names of the keys.
+func (k *Keystore) Names() []string {
+	names := make([]string, 0, len(k.Keys))
+	for name := range k.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Add encrypts the private key with the passphrase and stores it under the name.
+func (k *Keystore) Add(
+	name string,
+	privateKey crypto.PrivateKey,
+	hashAlgo crypto.HashAlgorithm,
+	passphrase string,
+) error {
+	if _, exists := k.Keys[name]; exists {
+		return fmt.Errorf("key with name %s already exists in the keystore", name)
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	params := keystoreKDFArgs{
+		N:      scryptN,
+		R:      scryptR,
+		P:      scryptP,
+		KeyLen: scryptKeyLen,
+		Salt:   hex.EncodeToString(salt),
+	}
+
+	gcm, err := keystoreCipherFor(passphrase, params)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	// the public key is authenticated as additional data so entries can't be tampered with
+	publicKey := privateKey.PublicKey().Encode()
+	ciphertext := gcm.Seal(nil, nonce, privateKey.Encode(), publicKey)
+
+	k.Keys[name] = KeystoreEntry{
+		SigAlgo:   privateKey.Algorithm().String(),
+		HashAlgo:  hashAlgo.String(),
+		PublicKey: hex.EncodeToString(publicKey),
+		Crypto: keystoreCrypto{
+			KDF:        keystoreKDF,
+			KDFParams:  params,
+			Cipher:     keystoreCipher,
+			Nonce:      hex.EncodeToString(nonce),
+			Ciphertext: hex.EncodeToString(ciphertext),
+		},
+	}
+
+	return nil
+}
+
+// Decrypt returns the private key stored under the name.
+func (k *Keystore) Decrypt(name string, passphrase string) (crypto.PrivateKey, crypto.HashAlgorithm, error) {
+	entry, ok := k.Keys[name]
+	if !ok {
+		return nil, crypto.UnknownHashAlgorithm, fmt.Errorf("key with name %s does not exist in the keystore", name)
+	}
+
+	if entry.Crypto.KDF != keystoreKDF || entry.Crypto.Cipher != keystoreCipher {
+		return nil, crypto.UnknownHashAlgorithm, fmt.Errorf(
+			"unsupported keystore encryption %s with %s",
+			entry.Crypto.Cipher,
+			entry.Crypto.KDF,
+		)
+	}
+
+	gcm, err := keystoreCipherFor(passphrase, entry.Crypto.KDFParams)
+	if err != nil {
+		return nil, crypto.UnknownHashAlgorithm, err
+	}
+
+	nonce, err := hex.DecodeString(entry.Crypto.Nonce)
+	if err != nil {
+		return nil, crypto.UnknownHashAlgorithm, fmt.Errorf("invalid keystore nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(entry.Crypto.Ciphertext)
+	if err != nil {
+		return nil, crypto.UnknownHashAlgorithm, fmt.Errorf("invalid keystore ciphertext: %w", err)
+	}
+	publicKey, err := hex.DecodeString(entry.PublicKey)
+	if err != nil {
+		return nil, crypto.UnknownHashAlgorithm, fmt.Errorf("invalid keystore public key: %w", err)
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, crypto.UnknownHashAlgorithm, fmt.Errorf("invalid keystore nonce length")
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, publicKey)
+	if err != nil {
+		return nil, crypto.UnknownHashAlgorithm, fmt.Errorf("failed to decrypt key %s, check the passphrase", name)
+	}
+
+	privateKey, err := crypto.DecodePrivateKey(crypto.StringToSignatureAlgorithm(entry.SigAlgo), plaintext)
+	if err != nil {
+		return nil, crypto.UnknownHashAlgorithm, fmt.Errorf("failed to decode key %s: %w", name, err)
+	}
+
+	return privateKey, crypto.StringToHashAlgorithm(entry.HashAlgo), nil
+}
+
+// keystoreCipherFor derives the encryption key from the passphrase using scrypt.
+func keystoreCipherFor(passphrase string, params keystoreKDFArgs) (cipher.AEAD, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore salt: %w", err)
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.KeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive keystore encryption key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// KeystorePassphrase reads the passphrase from the environment or prompts for it,
+// new passphrases need to be entered twice when prompted.
+func KeystorePassphrase(confirm bool) (string, error) {
+	if passphrase, ok := os.LookupEnv(KeystorePassphraseEnv); ok && passphrase != "" {
+		return passphrase, nil
+	}
+
+	passphrase := prompt.SecretPrompt("Enter keystore passphrase")
+	if confirm && prompt.SecretPrompt("Repeat keystore passphrase") != passphrase {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+
+	return passphrase, nil
+}
+
+var _ accounts.Key = &KeystoreKey{}
+
+// KeystoreKey implements signing with a key stored in the encrypted keystore.
+//
+// The key is referenced by its name in the keystore and is decrypted the first time it's used,
+// with the passphrase from the environment or a prompt.
+type KeystoreKey struct {
+	index      uint32
+	sigAlgo    crypto.SignatureAlgorithm
+	hashAlgo   crypto.HashAlgorithm
+	name       string
+	location   string
+	rw         flowkit.ReaderWriter
+	privateKey *crypto.PrivateKey
+}
+
+// NewKeystoreKey creates a key stored under the name in the keystore at the location,
+// an empty location refers to the keystore in the Flow CLI settings directory.
+func NewKeystoreKey(
+	name string,
+	location string,
+	index uint32,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+	rw flowkit.ReaderWriter,
+) (*KeystoreKey, error) {
+	if name == "" {
+		return nil, fmt.Errorf("keystore key name is required as the resourceID")
+	}
+
+	return &KeystoreKey{
+		index:    index,
+		sigAlgo:  sigAlgo,
+		hashAlgo: hashAlgo,
+		name:     name,
+		location: location,
+		rw:       rw,
+	}, nil
+}
+
+func (k *KeystoreKey) Type() config.KeyType {
+	return KeyTypeKeystore
+}
+
+func (k *KeystoreKey) Index() uint32 {
+	return k.index
+}
+
+func (k *KeystoreKey) SigAlgo() crypto.SignatureAlgorithm {
+	return k.sigAlgo
+}
+
+func (k *KeystoreKey) HashAlgo() crypto.HashAlgorithm {
+	return k.hashAlgo
+}
+
+// Name returns the name of the key in the keystore.
+func (k *KeystoreKey) Name() string {
+	return k.name
+}
+
+// ToConfig converts the key to configuration, the name is stored as the resource ID.
+func (k *KeystoreKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:       KeyTypeKeystore,
+		Index:      k.index,
+		SigAlgo:    k.sigAlgo,
+		HashAlgo:   k.hashAlgo,
+		ResourceID: k.name,
+		Location:   k.location,
+	}
+}
+
+func (k *KeystoreKey) Validate() error {
+	_, err := k.keystore()
+	return err
+}
+
+// keystore loads the keystore and checks it contains the key.
+func (k *KeystoreKey) keystore() (*Keystore, error) {
+	ks, err := LoadKeystore(k.rw, KeystorePath(k.location))
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := ks.Keys[k.name]
+	if !ok {
+		return nil, fmt.Errorf("key with name %s does not exist in the keystore", k.name)
+	}
+	if crypto.StringToSignatureAlgorithm(entry.SigAlgo) != k.sigAlgo {
+		return nil, fmt.Errorf("keystore key %s signature algorithm %s doesn't match %s", k.name, entry.SigAlgo, k.sigAlgo)
+	}
+
+	return ks, nil
+}
+
+// PrivateKey decrypts the key from the keystore, the passphrase is only requested once.
+func (k *KeystoreKey) PrivateKey() (*crypto.PrivateKey, error) {
+	if k.privateKey != nil {
+		return k.privateKey, nil
+	}
+
+	ks, err := k.keystore()
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase, err := KeystorePassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, _, err := ks.Decrypt(k.name, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	k.privateKey = &privateKey
+	return k.privateKey, nil
+}
+
+func (k *KeystoreKey) Signer(_ context.Context) (crypto.Signer, error) {
+	privateKey, err := k.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewInMemorySigner(*privateKey, k.hashAlgo)
+}
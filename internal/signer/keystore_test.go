@@ -0,0 +1,91 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPrivateKey = "cf3178b20a73846dc8bf6255c79be47178b0744dd8244bcff099e449a9700d7f"
+
+func Test_Keystore(t *testing.T) {
+	rw := &afero.Afero{Fs: afero.NewMemMapFs()}
+
+	key, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, testPrivateKey)
+	require.NoError(t, err)
+
+	t.Run("Encrypt and decrypt", func(t *testing.T) {
+		ks, err := LoadKeystore(rw, "encrypt.json")
+		require.NoError(t, err)
+
+		require.NoError(t, ks.Add("alice", key, crypto.SHA3_256, "secret"))
+		assert.EqualError(t, ks.Add("alice", key, crypto.SHA3_256, "secret"), "key with name alice already exists in the keystore")
+		assert.NotContains(t, ks.Keys["alice"].Crypto.Ciphertext, testPrivateKey)
+
+		decrypted, hashAlgo, err := ks.Decrypt("alice", "secret")
+		require.NoError(t, err)
+		assert.True(t, decrypted.Equals(key))
+		assert.Equal(t, crypto.SHA3_256, hashAlgo)
+
+		_, _, err = ks.Decrypt("alice", "wrong")
+		assert.EqualError(t, err, "failed to decrypt key alice, check the passphrase")
+
+		_, _, err = ks.Decrypt("bob", "secret")
+		assert.EqualError(t, err, "key with name bob does not exist in the keystore")
+	})
+
+	t.Run("Keystore key", func(t *testing.T) {
+		ks, err := LoadKeystore(rw, "keystore.json")
+		require.NoError(t, err)
+		require.NoError(t, ks.Add("alice", key, crypto.SHA3_256, "secret"))
+		require.NoError(t, ks.Save(rw, "keystore.json"))
+		t.Setenv(KeystorePassphraseEnv, "secret")
+
+		keystoreKey, err := NewKeystoreKey("alice", "keystore.json", 2, crypto.ECDSA_P256, crypto.SHA3_256, rw)
+		require.NoError(t, err)
+		assert.Equal(t, KeyTypeKeystore, keystoreKey.Type())
+		require.NoError(t, keystoreKey.Validate())
+
+		privateKey, err := keystoreKey.PrivateKey()
+		require.NoError(t, err)
+		assert.True(t, (*privateKey).Equals(key))
+
+		missing, err := NewKeystoreKey("bob", "keystore.json", 0, crypto.ECDSA_P256, crypto.SHA3_256, rw)
+		require.NoError(t, err)
+		assert.EqualError(t, missing.Validate(), "key with name bob does not exist in the keystore")
+
+		mismatch, err := NewKeystoreKey("alice", "keystore.json", 0, crypto.ECDSA_secp256k1, crypto.SHA3_256, rw)
+		require.NoError(t, err)
+		assert.EqualError(t, mismatch.Validate(), "keystore key alice signature algorithm ECDSA_P256 doesn't match ECDSA_secp256k1")
+
+		t.Setenv(KeystorePassphraseEnv, "wrong")
+		wrong, err := NewKeystoreKey("alice", "keystore.json", 0, crypto.ECDSA_P256, crypto.SHA3_256, rw)
+		require.NoError(t, err)
+		_, err = wrong.PrivateKey()
+		assert.EqualError(t, err, "failed to decrypt key alice, check the passphrase")
+
+		_, err = NewKeystoreKey("", "", 0, crypto.ECDSA_P256, crypto.SHA3_256, rw)
+		assert.EqualError(t, err, "keystore key name is required as the resourceID")
+	})
+}
@@ -0,0 +1,436 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"context"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/pkcs11"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/config"
+)
+
+// KeyTypePKCS11 is the key type of keys stored in a PKCS#11 token.
+const KeyTypePKCS11 config.KeyType = "pkcs11"
+
+// PinEnv is the environment variable the token user PIN is read from
+// when the key URI doesn't contain it.
+const PinEnv = "FLOW_PKCS11_PIN"
+
+var (
+	oidP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+var _ accounts.Key = &PKCS11Key{}
+
+// PKCS11Key implements signing with an EC key stored in a PKCS#11 token.
+//
+// The key is referenced with a PKCS#11 URI (RFC 7512) containing the slot, the key label
+// and the module path, for example:
+// pkcs11:slot-id=0;object=flow-key?module-path=/usr/lib/softhsm/libsofthsm2.so
+type PKCS11Key struct {
+	index    uint32
+	sigAlgo  crypto.SignatureAlgorithm
+	hashAlgo crypto.HashAlgorithm
+	uri      string
+	module   string
+	slot     uint
+	label    string
+	pin      string
+	signer   *pkcs11Signer
+}
+
+// NewPKCS11Key creates a key from the PKCS#11 URI.
+func NewPKCS11Key(
+	uri string,
+	index uint32,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*PKCS11Key, error) {
+	if sigAlgo != crypto.ECDSA_P256 && sigAlgo != crypto.ECDSA_secp256k1 {
+		return nil, fmt.Errorf("unsupported signature algorithm for PKCS#11 keys: %s", sigAlgo)
+	}
+
+	key := &PKCS11Key{
+		index:    index,
+		sigAlgo:  sigAlgo,
+		hashAlgo: hashAlgo,
+		uri:      withoutPin(uri),
+	}
+
+	if err := key.parseURI(uri); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// withoutPin removes the pin-value query attribute from the URI, so the PIN is not stored in the configuration.
+func withoutPin(uri string) string {
+	path, query, found := strings.Cut(uri, "?")
+	if !found {
+		return uri
+	}
+
+	attributes := make([]string, 0)
+	for _, attribute := range strings.Split(query, "&") {
+		if name, _, _ := strings.Cut(attribute, "="); name != "pin-value" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	if len(attributes) == 0 {
+		return path
+	}
+
+	return path + "?" + strings.Join(attributes, "&")
+}
+
+func (k *PKCS11Key) parseURI(uri string) error {
+	if !strings.HasPrefix(uri, "pkcs11:") {
+		return fmt.Errorf("invalid PKCS#11 URI %s, it must start with 'pkcs11:'", uri)
+	}
+
+	path, query, _ := strings.Cut(strings.TrimPrefix(uri, "pkcs11:"), "?")
+
+	slotFound := false
+	for _, attribute := range strings.Split(path, ";") {
+		name, value, _ := strings.Cut(attribute, "=")
+		value, err := url.PathUnescape(value)
+		if err != nil {
+			return fmt.Errorf("invalid PKCS#11 URI attribute %s: %w", name, err)
+		}
+
+		switch name {
+		case "slot-id":
+			slot, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid PKCS#11 slot %s", value)
+			}
+			k.slot = uint(slot)
+			slotFound = true
+		case "object":
+			k.label = value
+		}
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return fmt.Errorf("invalid PKCS#11 URI query: %w", err)
+	}
+	k.module = values.Get("module-path")
+	k.pin = values.Get("pin-value")
+
+	if k.module == "" {
+		return fmt.Errorf("PKCS#11 URI is missing the module-path")
+	}
+	if !slotFound {
+		return fmt.Errorf("PKCS#11 URI is missing the slot-id")
+	}
+	if k.label == "" {
+		return fmt.Errorf("PKCS#11 URI is missing the key label as object")
+	}
+
+	return nil
+}
+
+func (k *PKCS11Key) Type() config.KeyType {
+	return KeyTypePKCS11
+}
+
+func (k *PKCS11Key) Index() uint32 {
+	return k.index
+}
+
+func (k *PKCS11Key) SigAlgo() crypto.SignatureAlgorithm {
+	return k.sigAlgo
+}
+
+func (k *PKCS11Key) HashAlgo() crypto.HashAlgorithm {
+	return k.hashAlgo
+}
+
+// Module returns the path of the PKCS#11 module library.
+func (k *PKCS11Key) Module() string {
+	return k.module
+}
+
+// Slot returns the token slot the key is stored in.
+func (k *PKCS11Key) Slot() uint {
+	return k.slot
+}
+
+// Label returns the label of the key objects in the token.
+func (k *PKCS11Key) Label() string {
+	return k.label
+}
+
+// ToConfig converts the key to configuration, the URI without the PIN is stored as the resource ID.
+func (k *PKCS11Key) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:       KeyTypePKCS11,
+		Index:      k.index,
+		SigAlgo:    k.sigAlgo,
+		HashAlgo:   k.hashAlgo,
+		ResourceID: k.uri,
+	}
+}
+
+func (k *PKCS11Key) Validate() error {
+	if _, err := os.Stat(k.module); err != nil {
+		return fmt.Errorf("PKCS#11 module %s not found", k.module)
+	}
+
+	return nil
+}
+
+func (k *PKCS11Key) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible")
+}
+
+// Signer opens a session with the token and looks up the private and public key by label.
+//
+// The session stays open so multiple payloads can be signed, until the key is closed.
+func (k *PKCS11Key) Signer(_ context.Context) (crypto.Signer, error) {
+	if k.signer != nil {
+		return k.signer, nil
+	}
+
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+
+	pin := k.pin
+	if pin == "" {
+		pin = os.Getenv(PinEnv)
+	}
+
+	hasher, err := crypto.NewHasher(k.hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := pkcs11.New(k.module)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %s", k.module)
+	}
+
+	signer := &pkcs11Signer{ctx: ctx, hasher: hasher}
+
+	err = ctx.Initialize()
+	if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+		_ = signer.Close()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module: %w", err)
+	}
+	// the module is only finalized by whoever initialized it
+	signer.initialized = err == nil
+
+	signer.session, err = ctx.OpenSession(k.slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		_ = signer.Close()
+		return nil, fmt.Errorf("failed to open session on slot %d: %w", k.slot, err)
+	}
+	signer.sessionOpen = true
+
+	if pin != "" {
+		err = ctx.Login(signer.session, pkcs11.CKU_USER, pin)
+		if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+			_ = signer.Close()
+			return nil, fmt.Errorf("failed to log in to the token: %w", err)
+		}
+		signer.loggedIn = err == nil
+	}
+
+	signer.privateKey, err = findObject(ctx, signer.session, pkcs11.CKO_PRIVATE_KEY, k.label)
+	if err != nil {
+		_ = signer.Close()
+		return nil, err
+	}
+
+	signer.publicKey, err = k.publicKey(ctx, signer.session)
+	if err != nil {
+		_ = signer.Close()
+		return nil, err
+	}
+
+	k.signer = signer
+	return signer, nil
+}
+
+// Close logs out of the token and closes the session opened by the signer.
+func (k *PKCS11Key) Close() error {
+	if k.signer == nil {
+		return nil
+	}
+
+	err := k.signer.Close()
+	k.signer = nil
+	return err
+}
+
+// publicKey reads the EC point of the public key object and checks the curve matches the signature algorithm.
+func (k *PKCS11Key) publicKey(ctx *pkcs11.Ctx, session pkcs11.SessionHandle) (crypto.PublicKey, error) {
+	object, err := findObject(ctx, session, pkcs11.CKO_PUBLIC_KEY, k.label)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := ctx.GetAttributeValue(session, object, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key %s: %w", k.label, err)
+	}
+
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(attributes[0].Value, &curve); err != nil {
+		return nil, fmt.Errorf("failed to parse curve of key %s: %w", k.label, err)
+	}
+	if !curve.Equal(curveOID(k.sigAlgo)) {
+		return nil, fmt.Errorf("key %s curve %s doesn't match signature algorithm %s", k.label, curve, k.sigAlgo)
+	}
+
+	return decodeECPoint(k.sigAlgo, attributes[1].Value)
+}
+
+func curveOID(sigAlgo crypto.SignatureAlgorithm) asn1.ObjectIdentifier {
+	if sigAlgo == crypto.ECDSA_secp256k1 {
+		return oidSecp256k1
+	}
+
+	return oidP256
+}
+
+// decodeECPoint decodes an uncompressed EC point, tokens return it either raw
+// or wrapped in a DER octet string.
+func decodeECPoint(sigAlgo crypto.SignatureAlgorithm, value []byte) (crypto.PublicKey, error) {
+	point := value
+	var wrapped []byte
+	if rest, err := asn1.Unmarshal(value, &wrapped); err == nil && len(rest) == 0 {
+		point = wrapped
+	}
+
+	if len(point) == 0 || point[0] != 0x04 {
+		return nil, fmt.Errorf("public key is not an uncompressed EC point")
+	}
+
+	return crypto.DecodePublicKey(sigAlgo, point[1:])
+}
+
+func findObject(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, class uint, label string) (pkcs11.ObjectHandle, error) {
+	err := ctx.FindObjectsInit(session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to search token objects: %w", err)
+	}
+
+	objects, _, err := ctx.FindObjects(session, 2)
+	_ = ctx.FindObjectsFinal(session)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search token objects: %w", err)
+	}
+
+	kind := "public"
+	if class == pkcs11.CKO_PRIVATE_KEY {
+		kind = "private"
+	}
+
+	if len(objects) == 0 {
+		return 0, fmt.Errorf("%s key with label %s not found in the token", kind, label)
+	}
+	if len(objects) > 1 {
+		return 0, fmt.Errorf("multiple %s keys with label %s found in the token", kind, label)
+	}
+
+	return objects[0], nil
+}
+
+type pkcs11Signer struct {
+	ctx         *pkcs11.Ctx
+	initialized bool
+	session     pkcs11.SessionHandle
+	sessionOpen bool
+	loggedIn    bool
+	privateKey  pkcs11.ObjectHandle
+	publicKey   crypto.PublicKey
+	hasher      crypto.Hasher
+}
+
+// Close releases the token resources in the reverse order they were acquired, the first error is returned.
+func (s *pkcs11Signer) Close() error {
+	var errs []error
+	if s.loggedIn {
+		errs = append(errs, s.ctx.Logout(s.session))
+		s.loggedIn = false
+	}
+	if s.sessionOpen {
+		errs = append(errs, s.ctx.CloseSession(s.session))
+		s.sessionOpen = false
+	}
+	if s.initialized {
+		errs = append(errs, s.ctx.Finalize())
+		s.initialized = false
+	}
+	s.ctx.Destroy()
+
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to close PKCS#11 session: %w", err)
+		}
+	}
+	return nil
+}
+
+// Sign hashes the message and signs the digest in the token, CKM_ECDSA already produces
+// the r || s signature format used by Flow.
+func (s *pkcs11Signer) Sign(message []byte) ([]byte, error) {
+	digest := s.hasher.ComputeHash(message)
+
+	err := s.ctx.SignInit(s.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, s.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to sign: %w", err)
+	}
+
+	signature, err := s.ctx.Sign(s.session, digest)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to sign: %w", err)
+	}
+
+	if len(signature) != 64 {
+		return nil, fmt.Errorf("pkcs11: unexpected signature length %d", len(signature))
+	}
+
+	return signature, nil
+}
+
+func (s *pkcs11Signer) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
@@ -0,0 +1,215 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"context"
+	"encoding/asn1"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/miekg/pkcs11"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flowkit/v2/config"
+)
+
+func Test_PKCS11Key(t *testing.T) {
+	t.Run("Parse URI", func(t *testing.T) {
+		uri := "pkcs11:token=flow;slot-id=2;object=flow%20key?module-path=/usr/lib/softhsm/libsofthsm2.so&pin-value=1234"
+		key, err := NewPKCS11Key(uri, 1, crypto.ECDSA_secp256k1, crypto.SHA2_256)
+		require.NoError(t, err)
+
+		assert.Equal(t, "/usr/lib/softhsm/libsofthsm2.so", key.Module())
+		assert.Equal(t, uint(2), key.Slot())
+		assert.Equal(t, "flow key", key.Label())
+		assert.Equal(t, "1234", key.pin)
+		assert.Equal(t, config.AccountKey{
+			Type:       KeyTypePKCS11,
+			Index:      1,
+			SigAlgo:    crypto.ECDSA_secp256k1,
+			HashAlgo:   crypto.SHA2_256,
+			ResourceID: "pkcs11:token=flow;slot-id=2;object=flow%20key?module-path=/usr/lib/softhsm/libsofthsm2.so",
+		}, key.ToConfig())
+	})
+
+	t.Run("Strip PIN", func(t *testing.T) {
+		tests := map[string]string{
+			"pkcs11:slot-id=0;object=key?pin-value=1234&module-path=/lib.so": "pkcs11:slot-id=0;object=key?module-path=/lib.so",
+			"pkcs11:slot-id=0;object=key?module-path=/lib.so&pin-value=1234": "pkcs11:slot-id=0;object=key?module-path=/lib.so",
+			"pkcs11:slot-id=0;object=key?module-path=/lib.so":                "pkcs11:slot-id=0;object=key?module-path=/lib.so",
+			"pkcs11:slot-id=0;object=key?pin-value=1234":                     "pkcs11:slot-id=0;object=key",
+		}
+
+		for uri, expected := range tests {
+			assert.Equal(t, expected, withoutPin(uri))
+		}
+	})
+
+	t.Run("Fail invalid URI", func(t *testing.T) {
+		tests := map[string]string{
+			"slot-id=0;object=key?module-path=/lib.so":           "invalid PKCS#11 URI slot-id=0;object=key?module-path=/lib.so, it must start with 'pkcs11:'",
+			"pkcs11:slot-id=0;object=key":                        "PKCS#11 URI is missing the module-path",
+			"pkcs11:object=key?module-path=/lib.so":              "PKCS#11 URI is missing the slot-id",
+			"pkcs11:slot-id=0?module-path=/lib.so":               "PKCS#11 URI is missing the key label as object",
+			"pkcs11:slot-id=zero;object=key?module-path=/lib.so": "invalid PKCS#11 slot zero",
+		}
+
+		for uri, expected := range tests {
+			_, err := NewPKCS11Key(uri, 0, crypto.ECDSA_P256, crypto.SHA3_256)
+			assert.EqualError(t, err, expected)
+		}
+
+		_, err := NewPKCS11Key("pkcs11:slot-id=0;object=key?module-path=/lib.so", 0, crypto.BLS_BLS12_381, crypto.SHA3_256)
+		assert.EqualError(t, err, "unsupported signature algorithm for PKCS#11 keys: BLS_BLS12381")
+	})
+
+	t.Run("Fail missing module", func(t *testing.T) {
+		key, err := NewPKCS11Key("pkcs11:slot-id=0;object=key?module-path=/missing.so", 0, crypto.ECDSA_P256, crypto.SHA3_256)
+		require.NoError(t, err)
+
+		_, err = key.Signer(context.Background())
+		assert.EqualError(t, err, "PKCS#11 module /missing.so not found")
+	})
+
+	t.Run("Decode EC point", func(t *testing.T) {
+		point := append([]byte{0x04}, publicKeyBytes(t)...)
+		wrapped, err := asn1.Marshal(point)
+		require.NoError(t, err)
+
+		for _, value := range [][]byte{point, wrapped} {
+			key, err := decodeECPoint(crypto.ECDSA_P256, value)
+			require.NoError(t, err)
+			assert.Equal(t, publicKeyBytes(t), key.Encode())
+		}
+
+		_, err = decodeECPoint(crypto.ECDSA_P256, []byte{0x02, 0x01})
+		assert.EqualError(t, err, "public key is not an uncompressed EC point")
+	})
+}
+
+// Test_PKCS11Signer runs against SoftHSM or any other token, initialize one with:
+// softhsm2-util --init-token --free --label flow --pin 1234 --so-pin 1234
+// and set FLOW_TEST_PKCS11_MODULE to the module path, e.g. /usr/lib/softhsm/libsofthsm2.so
+func Test_PKCS11Signer(t *testing.T) {
+	module := os.Getenv("FLOW_TEST_PKCS11_MODULE")
+	if module == "" {
+		t.Skip("FLOW_TEST_PKCS11_MODULE not set")
+	}
+
+	pin := os.Getenv(PinEnv)
+	if pin == "" {
+		pin = "1234"
+	}
+
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		t.Run(sigAlgo.String(), func(t *testing.T) {
+			label := fmt.Sprintf("flow-cli-test-%d", time.Now().UnixNano())
+			slot := generateTokenKey(t, module, pin, label, sigAlgo)
+
+			uri := fmt.Sprintf("pkcs11:slot-id=%d;object=%s?module-path=%s&pin-value=%s", slot, label, module, pin)
+			key, err := NewPKCS11Key(uri, 0, sigAlgo, crypto.SHA3_256)
+			require.NoError(t, err)
+
+			signer, err := key.Signer(context.Background())
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, key.Close()) }()
+
+			message := []byte("flow")
+			signature, err := signer.Sign(message)
+			require.NoError(t, err)
+
+			hasher, err := crypto.NewHasher(crypto.SHA3_256)
+			require.NoError(t, err)
+
+			valid, err := signer.PublicKey().Verify(signature, message, hasher)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		})
+	}
+}
+
+func generateTokenKey(t *testing.T, module, pin, label string, sigAlgo crypto.SignatureAlgorithm) uint {
+	ctx := pkcs11.New(module)
+	require.NotNil(t, ctx)
+	_ = ctx.Initialize()
+
+	slots, err := ctx.GetSlotList(true)
+	require.NoError(t, err)
+
+	// SoftHSM also lists a slot with a token to initialize, so use the first initialized one
+	var slot uint
+	found := false
+	for _, id := range slots {
+		info, err := ctx.GetTokenInfo(id)
+		require.NoError(t, err)
+		if info.Flags&pkcs11.CKF_TOKEN_INITIALIZED != 0 {
+			slot, found = id, true
+			break
+		}
+	}
+	require.True(t, found, "no initialized token")
+
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	defer func() { _ = ctx.CloseSession(session) }()
+
+	err = ctx.Login(session, pkcs11.CKU_USER, pin)
+	if err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		require.NoError(t, err)
+	}
+
+	params, err := asn1.Marshal(curveOID(sigAlgo))
+	require.NoError(t, err)
+
+	_, _, err = ctx.GenerateKeyPair(
+		session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, params),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+		},
+	)
+	require.NoError(t, err)
+
+	return slot
+}
+
+func publicKeyBytes(t *testing.T) []byte {
+	key, err := crypto.DecodePublicKeyHex(
+		crypto.ECDSA_P256,
+		"d479b3cdc9edbddb195cb12b35161ade826b032a64bdd4062cc87fb3ba7e71c9cf646ff23990bb4532ca45c445c7e908cef278b2c4615360039a6660a366a95f",
+	)
+	require.NoError(t, err)
+
+	return key.Encode()
+}
@@ -23,12 +23,13 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/pkg/errors"
 	"github.com/radovskyb/watcher"
 
 	"github.com/onflow/flowkit/v2/config"
+
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 const (
@@ -58,13 +59,13 @@ type contractChange struct {
 func newProjectFiles(projectPath string) *projectFiles {
 	return &projectFiles{
 		cadencePath: filepath.Join(projectPath, cadenceDir),
-		watcher:     watcher.New(),
+		watcher:     util.NewFileWatcher(),
 	}
 }
 
 type projectFiles struct {
 	cadencePath string
-	watcher     *watcher.Watcher
+	watcher     *util.FileWatcher
 }
 
 // exist checks if current directory contains all project files required.
@@ -125,12 +126,7 @@ func (f *projectFiles) watch() (<-chan accountChange, <-chan contractChange, err
 		return nil, nil, errors.Wrap(err, "add recursive files failed")
 	}
 
-	go func() {
-		err = f.watcher.Start(500 * time.Millisecond)
-		if err != nil {
-			panic(err)
-		}
-	}()
+	f.watcher.Start()
 
 	accounts := make(chan accountChange)
 	contracts := make(chan contractChange)
@@ -145,7 +141,7 @@ func (f *projectFiles) watch() (<-chan accountChange, <-chan contractChange, err
 
 		for {
 			select {
-			case event := <-f.watcher.Event:
+			case event := <-f.watcher.Events():
 				rel, err := f.relProjectPath(event.Path)
 				if err != nil { // skip if failed
 					continue
@@ -179,7 +175,7 @@ func (f *projectFiles) watch() (<-chan accountChange, <-chan contractChange, err
 					oldPath: oldPath,
 					account: name,
 				}
-			case <-f.watcher.Closed:
+			case <-f.watcher.Closed():
 				close(contracts)
 				close(accounts)
 				return
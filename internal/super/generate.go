@@ -19,11 +19,9 @@
 package super
 
 import (
-	"bytes"
 	"embed"
 	"fmt"
 	"path/filepath"
-	"text/template"
 
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flowkit/v2/config"
@@ -301,12 +299,12 @@ func (g *Generator) generate(templateType, templateName, name, account string, d
 		for k, v := range data {
 			fileData[k] = v
 		}
-		fileToWrite, err = processTemplate(templatePath, fileData)
+		fileToWrite, err = util.ProcessTemplate(templatesFS, templatePath, fileData)
 		if err != nil {
 			return fmt.Errorf("error generating contract template: %w", err)
 		}
 
-		testFileToWrite, err = processTemplate("templates/contract_init_test.cdc.tmpl", fileData)
+		testFileToWrite, err = util.ProcessTemplate(templatesFS, "templates/contract_init_test.cdc.tmpl", fileData)
 		if err != nil {
 			return fmt.Errorf("error generating contract test template: %w", err)
 		}
@@ -316,7 +314,7 @@ func (g *Generator) generate(templateType, templateName, name, account string, d
 		for k, v := range data {
 			fileData[k] = v
 		}
-		fileToWrite, err = processTemplate(templatePath, fileData)
+		fileToWrite, err = util.ProcessTemplate(templatesFS, templatePath, fileData)
 		if err != nil {
 			return fmt.Errorf("error generating script template: %w", err)
 		}
@@ -326,7 +324,7 @@ func (g *Generator) generate(templateType, templateName, name, account string, d
 		for k, v := range data {
 			fileData[k] = v
 		}
-		fileToWrite, err = processTemplate(templatePath, fileData)
+		fileToWrite, err = util.ProcessTemplate(templatesFS, templatePath, fileData)
 		if err != nil {
 			return fmt.Errorf("error generating transaction template: %w", err)
 		}
@@ -417,25 +415,3 @@ func (g *Generator) updateContractsState(name, location string) error {
 
 	return nil
 }
-
-// processTemplate reads a template file from the embedded filesystem and processes it with the provided data
-// If you don't need to provide data, pass nil
-func processTemplate(templatePath string, data map[string]interface{}) (string, error) {
-	templateData, err := templatesFS.ReadFile(templatePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read template file: %w", err)
-	}
-
-	tmpl, err := template.New("template").Parse(string(templateData))
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
-	}
-
-	var executedTemplate bytes.Buffer
-	// Execute the template with the provided data or nil if no data is needed
-	if err = tmpl.Execute(&executedTemplate, data); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
-	}
-
-	return executedTemplate.String(), nil
-}
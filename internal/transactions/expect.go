@@ -0,0 +1,203 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// eventMatcher matches an event by type and optionally by the value of its fields.
+type eventMatcher struct {
+	eventType string
+	fields    map[string]string
+}
+
+// parseEventMatchers parses the expected events in the format
+// "A.0ae53cb6e3f42a79.FlowToken.TokensDeposited(amount=10.00000000, to=0x01)".
+//
+// Flag values are split on commas, so the parts of a matcher with multiple fields are joined back
+// until the closing parenthesis is found.
+func parseEventMatchers(values []string) ([]eventMatcher, error) {
+	joined := make([]string, 0, len(values))
+	for _, value := range values {
+		last := len(joined) - 1
+		if last >= 0 && strings.Contains(joined[last], "(") && !strings.HasSuffix(joined[last], ")") {
+			joined[last] = fmt.Sprintf("%s,%s", joined[last], value)
+			continue
+		}
+		joined = append(joined, strings.TrimSpace(value))
+	}
+
+	matchers := make([]eventMatcher, 0, len(joined))
+	for _, value := range joined {
+		if value == "" {
+			continue
+		}
+
+		eventType, rawFields, hasFields := strings.Cut(value, "(")
+		matcher := eventMatcher{
+			eventType: strings.TrimSpace(eventType),
+			fields:    make(map[string]string),
+		}
+
+		if hasFields {
+			if !strings.HasSuffix(rawFields, ")") {
+				return nil, fmt.Errorf("invalid expected event %s, missing closing parenthesis", value)
+			}
+			for _, field := range strings.Split(strings.TrimSuffix(rawFields, ")"), ",") {
+				name, expected, ok := strings.Cut(field, "=")
+				if !ok {
+					return nil, fmt.Errorf("invalid field matcher %s in expected event %s, use name=value", field, value)
+				}
+				matcher.fields[strings.TrimSpace(name)] = strings.TrimSpace(expected)
+			}
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers, nil
+}
+
+func (m eventMatcher) String() string {
+	if len(m.fields) == 0 {
+		return m.eventType
+	}
+
+	names := make([]string, 0, len(m.fields))
+	for name := range m.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]string, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, fmt.Sprintf("%s=%s", name, m.fields[name]))
+	}
+
+	return fmt.Sprintf("%s(%s)", m.eventType, strings.Join(fields, ", "))
+}
+
+func (m eventMatcher) matches(event flow.Event) bool {
+	if event.Type != m.eventType {
+		return false
+	}
+
+	values := cadence.FieldsMappedByName(event.Value)
+	for name, expected := range m.fields {
+		value, ok := values[name]
+		if !ok || !fieldValueMatches(value, expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldValueMatches compares the value to the expected value provided as a string,
+// strings are compared without quotes and addresses with or without the 0x prefix.
+func fieldValueMatches(value cadence.Value, expected string) bool {
+	if optional, ok := value.(cadence.Optional); ok {
+		if optional.Value == nil {
+			return expected == "nil"
+		}
+		value = optional.Value
+	}
+
+	switch v := value.(type) {
+	case cadence.String:
+		return string(v) == strings.Trim(expected, `"`)
+	case cadence.Address:
+		return flow.HexToAddress(expected) == flow.Address(v)
+	}
+
+	return value.String() == expected
+}
+
+// checkExpectations compares the transaction result with the expected outcome from the flags
+// and returns a description for each expectation that wasn't met.
+func checkExpectations(result *flow.TransactionResult, sendFlags Flags) ([]string, error) {
+	matchers, err := parseEventMatchers(sendFlags.ExpectEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := make([]string, 0)
+
+	if sendFlags.ExpectStatus != "" {
+		actual := result.Status.String()
+		if !strings.EqualFold(actual, sendFlags.ExpectStatus) {
+			failures = append(failures, fmt.Sprintf(
+				"expected status:\n%s",
+				util.LineDiff(strings.ToUpper(sendFlags.ExpectStatus), actual),
+			))
+		}
+	}
+
+	if sendFlags.ExpectError != "" {
+		if result.Error == nil {
+			failures = append(failures, fmt.Sprintf(
+				"expected error containing %q, but the transaction succeeded", sendFlags.ExpectError,
+			))
+		} else if !strings.Contains(result.Error.Error(), sendFlags.ExpectError) {
+			failures = append(failures, fmt.Sprintf(
+				"expected error containing %q, but got:\n%s", sendFlags.ExpectError, result.Error.Error(),
+			))
+		}
+	}
+
+	for _, matcher := range matchers {
+		found := false
+		for _, event := range result.Events {
+			if matcher.matches(event) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		emitted := make([]string, 0)
+		for _, event := range result.Events {
+			if event.Type == matcher.eventType {
+				emitted = append(emitted, event.Value.String())
+			}
+		}
+
+		if len(emitted) == 0 {
+			failures = append(failures, fmt.Sprintf("expected event %s was not emitted", matcher))
+		} else {
+			failures = append(failures, fmt.Sprintf(
+				"expected event %s was not emitted, events of this type:\n%s",
+				matcher,
+				util.LineDiff(matcher.String(), strings.Join(emitted, "\n")),
+			))
+		}
+	}
+
+	return failures, nil
+}
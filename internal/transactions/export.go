@@ -0,0 +1,395 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/accounts"
+	"github.com/onflow/flowkit/v2/arguments"
+	"github.com/onflow/flowkit/v2/config"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+type flagsExport struct {
+	Lang        string   `default:"js" flag:"lang" info:"Language of the exported code: js (FCL) or go (Flow Go SDK)"`
+	ArgsJSON    string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	Signer      string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and authorizer"`
+	Proposer    string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer"`
+	Payer       string   `default:"" flag:"payer" info:"Account name from configuration used as payer"`
+	Authorizers []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
+	GasLimit    uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var exportFlags = flagsExport{}
+
+var exportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "export <code filename> [<argument> <argument> ...]",
+		Short: "Export a transaction as FCL or Flow Go SDK code",
+		Args:  cobra.MinimumNArgs(1),
+		Example: `flow transactions export tx.cdc "Hello world" --lang js --network testnet --signer alice
+
+flow transactions export tx.cdc "Hello world" --lang go --save main.go`,
+	},
+	Flags: &exportFlags,
+	RunS:  export,
+}
+
+const (
+	exportLangJS = "js"
+	exportLangGo = "go"
+)
+
+// restAccessNodes are the access node REST APIs used by FCL for the default networks.
+var restAccessNodes = map[string]string{
+	config.EmulatorNetwork.Name:   "http://127.0.0.1:8888",
+	config.TestnetNetwork.Name:    "https://rest-testnet.onflow.org",
+	config.MainnetNetwork.Name:    "https://rest-mainnet.onflow.org",
+	config.PreviewnetNetwork.Name: "https://rest-previewnet.onflow.org",
+}
+
+func export(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	lang := strings.ToLower(exportFlags.Lang)
+	if lang != exportLangJS && lang != exportLangGo {
+		return nil, fmt.Errorf("unsupported language %s, use js or go", exportFlags.Lang)
+	}
+
+	filename := args[0]
+	code, err := state.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	roles, err := accountRoles(state, exportFlags.Signer, exportFlags.Proposer, exportFlags.Payer, exportFlags.Authorizers)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionArgs []cadence.Value
+	if exportFlags.ArgsJSON != "" {
+		transactionArgs, err = arguments.ParseJSON(exportFlags.ArgsJSON)
+	} else {
+		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	script, err := flow.ReplaceImportsInScript(
+		context.Background(),
+		flowkit.Script{Code: code, Args: transactionArgs, Location: filename},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving imports: %w", err)
+	}
+
+	data := exportData{
+		Network:    flow.Network().Name,
+		Host:       flow.Network().Host,
+		AccessNode: restAccessNodes[flow.Network().Name],
+		GasLimit:   exportFlags.GasLimit,
+	}
+	if data.AccessNode == "" {
+		data.AccessNode = flow.Network().Host
+	}
+
+	signers := make(map[string]exportSigner)
+	for _, account := range roles.Signers() {
+		signer := newExportSigner(account)
+		signer.Payer = account.Address == roles.Payer.Address
+		signers[signer.Address] = signer
+		data.Signers = append(data.Signers, signer)
+		data.UsesSHA3 = data.UsesSHA3 || signer.HashAlgo == crypto.SHA3_256.String()
+		data.UsesSHA2 = data.UsesSHA2 || signer.HashAlgo == crypto.SHA2_256.String()
+	}
+	data.Proposer = signers[roles.Proposer.Address.String()]
+	data.Payer = signers[roles.Payer.Address.String()]
+	for _, authorizer := range roles.Authorizers {
+		data.Authorizers = append(data.Authorizers, signers[authorizer.Address.String()])
+	}
+
+	var templateName string
+	switch lang {
+	case exportLangJS:
+		templateName = "templates/export_fcl.js.tmpl"
+		data.Code = escapeJSTemplate(string(script.Code))
+		for _, arg := range transactionArgs {
+			value, err := fclValue(arg)
+			if err != nil {
+				return nil, err
+			}
+			fclType, err := fclType(arg.Type())
+			if err != nil {
+				return nil, err
+			}
+			data.Arguments = append(data.Arguments, exportArgument{Value: value, Type: fclType})
+		}
+	case exportLangGo:
+		templateName = "templates/export_go.go.tmpl"
+		data.Code = goStringLiteral(string(script.Code))
+		for _, arg := range transactionArgs {
+			encoded, err := jsoncdc.Encode(arg)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding transaction argument: %w", err)
+			}
+			data.Arguments = append(data.Arguments, exportArgument{Value: goStringLiteral(strings.TrimSpace(string(encoded)))})
+		}
+	}
+
+	exported, err := util.ProcessTemplate(templatesFS, templateName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exportResult{lang: lang, code: exported}, nil
+}
+
+type exportData struct {
+	Network     string
+	Host        string
+	AccessNode  string
+	Code        string
+	GasLimit    uint64
+	Arguments   []exportArgument
+	Signers     []exportSigner
+	Proposer    exportSigner
+	Payer       exportSigner
+	Authorizers []exportSigner
+	UsesSHA3    bool
+	UsesSHA2    bool
+}
+
+type exportArgument struct {
+	Value string
+	Type  string
+}
+
+type exportSigner struct {
+	Name     string
+	Var      string
+	EnvVar   string
+	Address  string
+	KeyIndex uint32
+	SigAlgo  string
+	HashAlgo string
+	Curve    string
+	Payer    bool
+}
+
+func newExportSigner(account *accounts.Account) exportSigner {
+	curve := "p256"
+	if account.Key.SigAlgo() == crypto.ECDSA_secp256k1 {
+		curve = "secp256k1"
+	}
+
+	return exportSigner{
+		Name:     account.Name,
+		Var:      identifier(account.Name),
+		EnvVar:   fmt.Sprintf("%s_PRIVATE_KEY", strings.ToUpper(strings.Join(nameWords(account.Name), "_"))),
+		Address:  account.Address.String(),
+		KeyIndex: account.Key.Index(),
+		SigAlgo:  account.Key.SigAlgo().String(),
+		HashAlgo: account.Key.HashAlgo().String(),
+		Curve:    curve,
+	}
+}
+
+// nameWords splits an account name like "emulator-account" into words.
+func nameWords(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// identifier converts an account name into a camel case identifier valid in JavaScript and Go.
+func identifier(name string) string {
+	words := nameWords(name)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word[:1]) + word[1:]
+		} else {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	id := strings.Join(words, "")
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "account" + id
+	}
+
+	return id
+}
+
+// escapeJSTemplate escapes the code so it can be used inside a JavaScript template literal.
+func escapeJSTemplate(code string) string {
+	return strings.NewReplacer(`\`, `\\`, "`", "\\`", "${", "\\${").Replace(code)
+}
+
+// goStringLiteral returns a raw string literal if possible, so the code stays readable.
+func goStringLiteral(value string) string {
+	if strings.Contains(value, "`") {
+		return strconv.Quote(value)
+	}
+	return fmt.Sprintf("`%s`", value)
+}
+
+// fclType returns the FCL type used to encode an argument of the Cadence type.
+func fclType(t cadence.Type) (string, error) {
+	switch typ := t.(type) {
+	case *cadence.OptionalType:
+		inner, err := fclType(typ.Type)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("t.Optional(%s)", inner), nil
+	case *cadence.VariableSizedArrayType:
+		inner, err := fclType(typ.ElementType)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("t.Array(%s)", inner), nil
+	case *cadence.ConstantSizedArrayType:
+		inner, err := fclType(typ.ElementType)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("t.Array(%s)", inner), nil
+	case *cadence.DictionaryType:
+		key, err := fclType(typ.KeyType)
+		if err != nil {
+			return "", err
+		}
+		value, err := fclType(typ.ElementType)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("t.Dictionary({ key: %s, value: %s })", key, value), nil
+	}
+
+	if t == nil {
+		return "", fmt.Errorf("argument type can not be exported for FCL")
+	}
+
+	switch id := t.ID(); id {
+	case "StoragePath", "PublicPath", "PrivatePath", "Path":
+		return "t.Path", nil
+	case "String", "Character", "Bool", "Address",
+		"Int", "Int8", "Int16", "Int32", "Int64", "Int128", "Int256",
+		"UInt", "UInt8", "UInt16", "UInt32", "UInt64", "UInt128", "UInt256",
+		"Word8", "Word16", "Word32", "Word64", "Word128", "Word256",
+		"Fix64", "UFix64":
+		return fmt.Sprintf("t.%s", id), nil
+	default:
+		return "", fmt.Errorf("argument type %s can not be exported for FCL", id)
+	}
+}
+
+// fclValue returns the JavaScript value FCL expects for the argument,
+// numbers are passed as strings to keep their precision.
+func fclValue(value cadence.Value) (string, error) {
+	switch v := value.(type) {
+	case cadence.String:
+		return strconv.Quote(string(v)), nil
+	case cadence.Character:
+		return strconv.Quote(string(v)), nil
+	case cadence.Bool:
+		return v.String(), nil
+	case cadence.Address:
+		return strconv.Quote(fmt.Sprintf("0x%s", v.Hex())), nil
+	case cadence.Optional:
+		if v.Value == nil {
+			return "null", nil
+		}
+		return fclValue(v.Value)
+	case cadence.Array:
+		values := make([]string, 0, len(v.Values))
+		for _, element := range v.Values {
+			value, err := fclValue(element)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, value)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(values, ", ")), nil
+	case cadence.Dictionary:
+		pairs := make([]string, 0, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			key, err := fclValue(pair.Key)
+			if err != nil {
+				return "", err
+			}
+			value, err := fclValue(pair.Value)
+			if err != nil {
+				return "", err
+			}
+			pairs = append(pairs, fmt.Sprintf("{ key: %s, value: %s }", key, value))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(pairs, ", ")), nil
+	case cadence.Path:
+		return fmt.Sprintf("{ domain: %q, identifier: %q }", v.Domain.Identifier(), v.Identifier), nil
+	case cadence.NumberValue:
+		return strconv.Quote(v.String()), nil
+	default:
+		return "", fmt.Errorf("argument %s can not be exported for FCL", value)
+	}
+}
+
+type exportResult struct {
+	lang string
+	code string
+}
+
+func (r *exportResult) JSON() any {
+	return map[string]any{
+		"lang": r.lang,
+		"code": r.code,
+	}
+}
+
+func (r *exportResult) String() string {
+	return r.code
+}
+
+func (r *exportResult) Oneliner() string {
+	return r.code
+}
@@ -0,0 +1,279 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-emulator/convert"
+	"github.com/onflow/flow-emulator/emulator"
+	"github.com/onflow/flow-emulator/storage/remote"
+	"github.com/onflow/flow-emulator/storage/sqlite"
+	"github.com/onflow/flow-emulator/types"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	flowgo "github.com/onflow/flow-go/model/flow"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/config"
+	"github.com/onflow/flowkit/v2/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsReplay struct {
+	Height uint64 `default:"0" flag:"height" info:"Block height of the state used for the replay, defaults to the block before the transaction"`
+}
+
+var replayFlags = flagsReplay{}
+
+var replayCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "replay <tx_id>",
+		Short: "Replay a transaction in a local emulator forked at the historical state",
+		Long: `Replay a transaction in an in-process emulator forked from the network at the block before the transaction.
+The state the transaction touches, including the contracts it imports, is fetched on demand from the network,
+and the transaction is executed again with debug logging so the log() output and the Cadence stack trace are shown.`,
+		Example: "flow transactions replay 07a8...b433 --network mainnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &replayFlags,
+	Run:   replay,
+}
+
+// replayChains are the networks which support forking the state at a historical block height.
+var replayChains = map[string]flowgo.ChainID{
+	config.MainnetNetwork.Name: flowgo.Mainnet,
+	config.TestnetNetwork.Name: flowgo.Testnet,
+}
+
+// replayExecutor executes a transaction on top of the forked state.
+type replayExecutor interface {
+	AddTransaction(tx flowgo.TransactionBody) error
+	ExecuteNextTransaction() (*types.TransactionResult, error)
+}
+
+// newReplayExecutor creates an in-process emulator which reads the state of the network at the provided height,
+// the returned function releases the storage and the connection to the network.
+var newReplayExecutor = func(
+	network config.Network,
+	height uint64,
+	logger zerolog.Logger,
+) (replayExecutor, func(), error) {
+	chainID, ok := replayChains[network.Name]
+	if !ok {
+		return nil, nil, fmt.Errorf("transaction replay is only supported on mainnet and testnet networks")
+	}
+
+	provider, err := sqlite.New(sqlite.InMemory)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	store, err := remote.New(
+		provider,
+		&logger,
+		remote.WithRPCHost(network.Host, chainID),
+		remote.WithStartBlockHeight(height),
+	)
+	if err != nil {
+		_ = provider.Close()
+		return nil, nil, fmt.Errorf("failed to fork the network state: %w", err)
+	}
+
+	closeStore := func() {
+		store.Stop()
+		_ = provider.Close()
+	}
+
+	blockchain, err := emulator.New(
+		emulator.WithStore(store),
+		emulator.WithChainID(chainID),
+		emulator.WithLogger(logger),
+		emulator.WithServerLogger(logger),
+		emulator.WithTransactionValidationEnabled(false),
+		emulator.WithStorageLimitEnabled(false),
+	)
+	if err != nil {
+		closeStore()
+		return nil, nil, fmt.Errorf("failed to start the emulator: %w", err)
+	}
+
+	return blockchain, closeStore, nil
+}
+
+func replay(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	id := flowsdk.HexToID(strings.TrimPrefix(args[0], "0x"))
+
+	logger.StartProgress("Fetching transaction...")
+	tx, result, err := flow.GetTransactionByID(context.Background(), id, true)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	height := replayFlags.Height
+	if height == 0 {
+		if result == nil || result.BlockHeight == 0 {
+			return nil, fmt.Errorf("transaction %s was not executed yet, provide the height with --height", id)
+		}
+		height = result.BlockHeight - 1
+	}
+
+	logger.StartProgress(fmt.Sprintf("Forking %s state at height %d...", flow.Network().Name, height))
+	debugLogger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger().Level(zerolog.DebugLevel)
+	executor, closeExecutor, err := newReplayExecutor(flow.Network(), height, debugLogger)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+	defer closeExecutor()
+
+	// signatures were already verified on the network and the reference block is not part of the forked state,
+	// so the transaction is executed without them
+	body := convert.SDKTransactionToFlow(*tx)
+	body.ReferenceBlockID = flowgo.ZeroID
+
+	if err := executor.AddTransaction(*body); err != nil {
+		return nil, fmt.Errorf("failed to add transaction to the emulator: %w", err)
+	}
+
+	replayed, err := executor.ExecuteNextTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute transaction: %w", err)
+	}
+
+	return &replayResult{
+		id:       id,
+		height:   height,
+		original: result,
+		result:   replayed,
+	}, nil
+}
+
+type replayResult struct {
+	id       flowsdk.Identifier
+	height   uint64
+	original *flowsdk.TransactionResult
+	result   *types.TransactionResult
+}
+
+func (r *replayResult) JSON() any {
+	result := map[string]any{
+		"id":               r.id.String(),
+		"height":           r.height,
+		"computation_used": r.result.ComputationUsed,
+		"logs":             r.result.Logs,
+	}
+
+	txEvents := make([]any, 0, len(r.result.Events))
+	for _, event := range r.result.Events {
+		txEvents = append(txEvents, map[string]any{
+			"index":  event.EventIndex,
+			"type":   event.Type,
+			"values": json.RawMessage(event.Payload),
+		})
+	}
+	result["events"] = txEvents
+
+	if r.result.Error != nil {
+		result["error"] = r.result.Error.Error()
+	}
+	if r.result.Debug != nil {
+		result["debug"] = map[string]any{
+			"message": r.result.Debug.Message,
+			"meta":    r.result.Debug.Meta,
+		}
+	}
+	if r.original != nil && r.original.Error != nil {
+		result["original_error"] = r.original.Error.Error()
+	}
+
+	return result
+}
+
+func (r *replayResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.id)
+	_, _ = fmt.Fprintf(writer, "Replayed At Height\t%d\n", r.height)
+	_, _ = fmt.Fprintf(writer, "Computation Used\t%d\n", r.result.ComputationUsed)
+
+	if r.result.Succeeded() {
+		_, _ = fmt.Fprintf(writer, "Status\t%s Succeeded\n", output.OkEmoji())
+	} else {
+		_, _ = fmt.Fprintf(writer, "Status\t%s Reverted\n", output.ErrorEmoji())
+	}
+
+	if len(r.result.Logs) == 0 {
+		_, _ = fmt.Fprintf(writer, "\nLogs:\t None\n")
+	} else {
+		_, _ = fmt.Fprintf(writer, "\nLogs:\n")
+		for _, log := range r.result.Logs {
+			_, _ = fmt.Fprintf(writer, "    %s\n", log)
+		}
+	}
+
+	e := events.EventResult{Events: r.result.Events}
+	eventsOutput := e.String()
+	if eventsOutput == "" {
+		eventsOutput = "None"
+	}
+	_, _ = fmt.Fprintf(writer, "\nEvents:\t %s\n", eventsOutput)
+
+	if r.result.Error != nil {
+		_, _ = fmt.Fprintf(writer, "\n%s Transaction Error \n%s\n", output.ErrorEmoji(), r.result.Error.Error())
+	}
+	if r.result.Debug != nil && r.result.Debug.Message != "" {
+		_, _ = fmt.Fprintf(writer, "\nDebug\t%s\n", r.result.Debug.Message)
+	}
+
+	if r.original != nil && (r.original.Error == nil) != r.result.Succeeded() {
+		_, _ = fmt.Fprintf(
+			writer,
+			"\n%s The replayed outcome differs from the network, transactions executed earlier in the same block are not replayed.\n",
+			output.TryEmoji(),
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *replayResult) Oneliner() string {
+	if r.result.Error != nil {
+		return fmt.Sprintf("Replayed %s at height %d, error: %s", r.id, r.height, r.result.Error.Error())
+	}
+	return fmt.Sprintf("Replayed %s at height %d, logs: %s", r.id, r.height, strings.Join(r.result.Logs, ", "))
+}
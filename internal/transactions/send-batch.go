@@ -0,0 +1,554 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/arguments"
+	"github.com/onflow/flowkit/v2/output"
+	"github.com/onflow/flowkit/v2/transactions"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSendBatch struct {
+	Input       string  `default:"" flag:"input" info:"CSV or JSONL file with one row of transaction arguments per line"`
+	Results     string  `default:"" flag:"results" info:"File where results are recorded, used to resume an interrupted run (default: <input>.results.jsonl)"`
+	Signer      string  `default:"" flag:"signer" info:"Account name from configuration used to sign the transactions as proposer, payer and authorizer (default: emulator service account)"`
+	Concurrency int     `default:"10" flag:"concurrency" info:"Maximum number of transactions waiting to be sealed at the same time"`
+	Rate        float64 `default:"0" flag:"rate" info:"Maximum number of transactions sent per second, 0 means unlimited"`
+	GasLimit    uint64  `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var sendBatchFlags = flagsSendBatch{}
+
+var sendBatchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "send-batch <code filename> --input <rows file>",
+		Short: "Send the same transaction for each row of arguments in a CSV or JSONL file",
+		Example: `# send a transaction for each CSV row, where each column is an argument
+flow transactions send-batch airdrop.cdc --input rows.csv --signer alice
+
+# each JSONL line is an array of arguments in JSON-Cadence format
+flow transactions send-batch airdrop.cdc --input rows.jsonl --concurrency 20 --rate 5`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &sendBatchFlags,
+	RunS:  sendBatch,
+}
+
+const batchStatusPending = "PENDING"
+
+// batchRecord is a single line of the results file.
+//
+// A record with an ID and a pending status is written as soon as the transaction is accepted
+// by the network, so an interrupted run waits for it instead of sending the row again.
+type batchRecord struct {
+	Row    int    `json:"row"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (r batchRecord) done() bool {
+	return r.Status != "" && r.Status != batchStatusPending
+}
+
+func (r batchRecord) failed() bool {
+	return r.Error != ""
+}
+
+type batchRow struct {
+	number int
+	args   []cadence.Value
+	err    error
+}
+
+func sendBatch(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if sendBatchFlags.Input == "" {
+		return nil, fmt.Errorf("input file is required, use --input flag")
+	}
+	if sendBatchFlags.Concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1")
+	}
+
+	filename := args[0]
+	code, err := state.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	input, err := state.ReadFile(sendBatchFlags.Input)
+	if err != nil {
+		return nil, fmt.Errorf("error loading input file: %w", err)
+	}
+
+	rows, err := parseBatchRows(input, sendBatchFlags.Input, code, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	signerName := sendBatchFlags.Signer
+	if signerName == "" {
+		signerName = state.Config().Emulators.Default().ServiceAccount
+	}
+
+	signer, err := state.Accounts().ByName(signerName)
+	if err != nil {
+		return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
+	}
+
+	resultsFile := sendBatchFlags.Results
+	if resultsFile == "" {
+		resultsFile = batchResultsFilename(sendBatchFlags.Input)
+	}
+
+	results, err := newBatchResults(state.ReaderWriter(), resultsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := &batchSender{
+		flow:     flow,
+		roles:    transactions.SingleAccountRole(*signer),
+		code:     code,
+		location: filename,
+		results:  results,
+		logger:   logger,
+	}
+
+	logger.StartProgress(fmt.Sprintf("Sending %d transactions...", len(rows)))
+	defer logger.StopProgress()
+
+	err = sender.run(context.Background(), rows, sendBatchFlags.Concurrency, sendBatchFlags.Rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchResult{
+		records:     results.all(),
+		resultsFile: resultsFile,
+	}, nil
+}
+
+// batchResultsFilename derives the default results file from the input filename.
+func batchResultsFilename(input string) string {
+	return fmt.Sprintf("%s.results.jsonl", strings.TrimSuffix(input, filepath.Ext(input)))
+}
+
+// parseBatchRows parses the input file into rows of transaction arguments.
+//
+// JSONL input (.jsonl, .ndjson) contains one JSON-Cadence argument array per line,
+// any other input is read as CSV where every column is an argument parsed using the transaction parameter types.
+// Rows that can't be parsed are returned with an error, so they are reported instead of failing the whole batch.
+func parseBatchRows(input []byte, inputFilename string, code []byte, location string) ([]batchRow, error) {
+	var rows []batchRow
+
+	switch strings.ToLower(filepath.Ext(inputFilename)) {
+	case ".jsonl", ".ndjson":
+		scanner := bufio.NewScanner(bytes.NewReader(input))
+		scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			values, err := arguments.ParseJSON(line)
+			rows = append(rows, batchRow{number: len(rows) + 1, args: values, err: err})
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error reading input file: %w", err)
+		}
+	default:
+		reader := csv.NewReader(bytes.NewReader(input))
+		reader.Comment = '#'
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("error reading input file: %w", err)
+		}
+		for _, record := range records {
+			values, err := arguments.ParseWithoutType(record, code, location)
+			rows = append(rows, batchRow{number: len(rows) + 1, args: values, err: err})
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("input file %s doesn't contain any rows", inputFilename)
+	}
+
+	return rows, nil
+}
+
+// batchResults keeps the latest record for each row and appends every change to the results file.
+type batchResults struct {
+	mu       sync.Mutex
+	rw       flowkit.ReaderWriter
+	filename string
+	records  map[int]batchRecord
+}
+
+// appender is implemented by file systems that can open a file for appending, such as afero.
+type appender interface {
+	OpenFile(name string, flag int, perm os.FileMode) (afero.File, error)
+}
+
+func newBatchResults(rw flowkit.ReaderWriter, filename string) (*batchResults, error) {
+	results := &batchResults{
+		rw:       rw,
+		filename: filename,
+		records:  make(map[int]batchRecord),
+	}
+
+	if _, err := rw.Stat(filename); err != nil {
+		return results, nil // nothing to resume
+	}
+
+	existing, err := rw.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading results file: %w", err)
+	}
+
+	for i, line := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var record batchRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return nil, fmt.Errorf("invalid record on line %d of results file %s: %w", i+1, filename, err)
+		}
+		results.records[record.Row] = record
+	}
+
+	return results, nil
+}
+
+func (b *batchResults) get(row int) (batchRecord, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	record, ok := b.records[row]
+	return record, ok
+}
+
+func (b *batchResults) record(record batchRecord) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.records[record.Row] = record
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if a, ok := b.rw.(appender); ok {
+		file, err := a.OpenFile(b.filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("error writing results file: %w", err)
+		}
+		defer file.Close()
+		_, err = file.Write(line)
+		return err
+	}
+
+	existing, _ := b.rw.ReadFile(b.filename)
+	return b.rw.WriteFile(b.filename, append(existing, line...), 0644)
+}
+
+func (b *batchResults) all() []batchRecord {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	records := make([]batchRecord, 0, len(b.records))
+	for _, r := range b.records {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Row < records[j].Row
+	})
+
+	return records
+}
+
+// batchSender sends the transactions one by one, managing the proposer sequence number locally
+// so transactions don't have to be sealed before the next one is sent, while a pool of workers waits for the results.
+type batchSender struct {
+	flow     flowkit.Services
+	roles    transactions.AccountRoles
+	code     []byte
+	location string
+	results  *batchResults
+	logger   output.Logger
+	sequence *uint64
+}
+
+type sentTransaction struct {
+	row int
+	id  flowsdk.Identifier
+}
+
+func (s *batchSender) run(ctx context.Context, rows []batchRow, concurrency int, rate float64) error {
+	// a slot is taken before a transaction is sent and released once its result is received,
+	// so no more than concurrency transactions wait to be sealed at the same time
+	pending := make(chan struct{}, concurrency)
+	sent := make(chan sentTransaction)
+	errs := make(chan error, concurrency)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for tx := range sent {
+				err := s.await(ctx, tx)
+				<-pending
+				if err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+	var throttle <-chan time.Time
+	if rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	err := func() error {
+		defer close(sent)
+
+		for _, row := range rows {
+			select {
+			case err := <-errs:
+				return err
+			default:
+			}
+
+			previous, ok := s.results.get(row.number)
+			if ok && previous.done() {
+				continue
+			}
+			if ok && previous.ID != "" { // sent before the previous run stopped, only wait for the result
+				pending <- struct{}{}
+				sent <- sentTransaction{row: row.number, id: flowsdk.HexToID(previous.ID)}
+				continue
+			}
+
+			if row.err != nil {
+				if err := s.results.record(batchRecord{
+					Row:   row.number,
+					Error: fmt.Sprintf("error parsing transaction arguments: %s", row.err),
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+
+			pending <- struct{}{}
+			if throttle != nil {
+				<-throttle
+			}
+
+			id, err := s.send(ctx, row)
+			if err != nil {
+				<-pending
+				if err := s.results.record(batchRecord{Row: row.number, Error: err.Error()}); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := s.results.record(batchRecord{
+				Row:    row.number,
+				ID:     id.String(),
+				Status: batchStatusPending,
+			}); err != nil {
+				return err
+			}
+			sent <- sentTransaction{row: row.number, id: id}
+		}
+		return nil
+	}()
+
+	wg.Wait()
+	close(errs)
+	if err != nil {
+		return err
+	}
+
+	return <-errs
+}
+
+// send builds, signs and sends the transaction for a row, without waiting for the result.
+func (s *batchSender) send(ctx context.Context, row batchRow) (flowsdk.Identifier, error) {
+	tx, err := s.flow.BuildTransaction(
+		ctx,
+		s.roles.AddressRoles(),
+		s.roles.Proposer.Key.Index(),
+		flowkit.Script{Code: s.code, Args: row.args, Location: s.location},
+		sendBatchFlags.GasLimit,
+	)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+
+	// the sequence number from the network doesn't include the transactions still pending
+	proposalKey := tx.FlowTransaction().ProposalKey
+	if s.sequence == nil || proposalKey.SequenceNumber > *s.sequence {
+		s.sequence = &proposalKey.SequenceNumber
+	}
+	tx.FlowTransaction().SetProposalKey(proposalKey.Address, proposalKey.KeyIndex, *s.sequence)
+
+	for _, signer := range s.roles.Signers() {
+		payload := []byte(hex.EncodeToString(tx.FlowTransaction().Encode()))
+		tx, err = s.flow.SignTransactionPayload(ctx, signer, payload)
+		if err != nil {
+			return flowsdk.EmptyID, err
+		}
+	}
+
+	sentTx, err := s.flow.Gateway().SendSignedTransaction(ctx, tx.FlowTransaction())
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+
+	next := *s.sequence + 1
+	s.sequence = &next
+
+	return sentTx.ID(), nil
+}
+
+// await waits for the transaction to be sealed and records the result.
+func (s *batchSender) await(ctx context.Context, tx sentTransaction) error {
+	record := batchRecord{Row: tx.row, ID: tx.id.String()}
+
+	result, err := s.flow.Gateway().GetTransactionResult(ctx, tx.id, true)
+	if err != nil {
+		record.Status = batchStatusPending
+		record.Error = err.Error()
+	} else {
+		record.Status = result.Status.String()
+		if result.Error != nil {
+			record.Error = result.Error.Error()
+		}
+	}
+
+	if err := s.results.record(record); err != nil {
+		return err
+	}
+
+	if record.failed() {
+		s.logger.Debug(fmt.Sprintf("Row %d failed: %s", record.Row, record.Error))
+	}
+
+	return nil
+}
+
+type batchResult struct {
+	records     []batchRecord
+	resultsFile string
+}
+
+func (r *batchResult) failed() []batchRecord {
+	failed := make([]batchRecord, 0)
+	for _, record := range r.records {
+		if record.failed() {
+			failed = append(failed, record)
+		}
+	}
+	return failed
+}
+
+func (r *batchResult) JSON() any {
+	return map[string]any{
+		"results":      r.records,
+		"results_file": r.resultsFile,
+		"total":        len(r.records),
+		"failed":       len(r.failed()),
+	}
+}
+
+func (r *batchResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	failed := r.failed()
+	_, _ = fmt.Fprintf(writer, "Transactions\t%d\n", len(r.records))
+	_, _ = fmt.Fprintf(writer, "Succeeded\t%d\n", len(r.records)-len(failed))
+	_, _ = fmt.Fprintf(writer, "Failed\t%d\n", len(failed))
+	_, _ = fmt.Fprintf(writer, "Results File\t%s\n", r.resultsFile)
+
+	if len(failed) > 0 {
+		_, _ = fmt.Fprintf(writer, "\n%s Failed Rows:\n", output.ErrorEmoji())
+		for _, record := range failed {
+			_, _ = fmt.Fprintf(writer, "    Row %d\t%s\n", record.Row, record.Error)
+		}
+		_, _ = fmt.Fprintf(writer, "\n%s Run the same command again to retry the failed rows that were not sent.\n", output.TryEmoji())
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *batchResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Transactions: %d, Failed: %d, Results File: %s",
+		len(r.records), len(r.failed()), r.resultsFile,
+	)
+}
+
+func (r *batchResult) ExitCode() int {
+	if len(r.failed()) > 0 {
+		return 1
+	}
+	return 0
+}
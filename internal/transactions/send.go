@@ -35,24 +35,30 @@ import (
 )
 
 type Flags struct {
-	ArgsJSON    string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	Signer      string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and suthorizer"`
-	Proposer    string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer"`
-	Payer       string   `default:"" flag:"payer" info:"Account name from configuration used as payer"`
-	Authorizers []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
-	Include     []string `default:"" flag:"include" info:"Fields to include in the output"`
-	Exclude     []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
-	GasLimit    uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	ArgsJSON     string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	Signer       string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and suthorizer"`
+	Proposer     string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer"`
+	Payer        string   `default:"" flag:"payer" info:"Account name from configuration used as payer"`
+	Authorizers  []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
+	Include      []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude      []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	GasLimit     uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	ExpectStatus string   `default:"" flag:"expect-status" info:"Expected transaction status, exits with a non-zero code if not matched"`
+	ExpectEvents []string `default:"" flag:"expect-event" info:"Expected event type with optional field matchers, e.g. A.1654653399040a61.FlowToken.TokensDeposited(amount=10.00000000)"`
+	ExpectError  string   `default:"" flag:"expect-error" info:"Expected substring of the transaction error, exits with a non-zero code if not matched"`
 }
 
 var flags = Flags{}
 
 var sendCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "send <code filename> [<argument> <argument> ...]",
-		Short:   "Send a transaction",
-		Args:    cobra.MinimumNArgs(1),
-		Example: `flow transactions send tx.cdc "Hello world"`,
+		Use:   "send <code filename> [<argument> <argument> ...]",
+		Short: "Send a transaction",
+		Args:  cobra.MinimumNArgs(1),
+		Example: `flow transactions send tx.cdc "Hello world"
+
+# exit with a non-zero code if the transaction doesn't have the expected outcome
+flow transactions send tx.cdc --expect-status sealed --expect-event 'A.0ae53cb6e3f42a79.FlowToken.TokensDeposited(amount=10.00000000)'`,
 	},
 	Flags: &flags,
 	RunS:  send,
@@ -76,7 +82,61 @@ func send(
 }
 
 func SendTransaction(code []byte, args []string, location string, flow flowkit.Services, state *flowkit.State, sendFlags Flags) (result command.Result, err error) {
-	proposerName := sendFlags.Proposer
+	roles, err := accountRoles(state, sendFlags.Signer, sendFlags.Proposer, sendFlags.Payer, sendFlags.Authorizers)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionArgs []cadence.Value
+	if sendFlags.ArgsJSON != "" {
+		transactionArgs, err = arguments.ParseJSON(sendFlags.ArgsJSON)
+	} else {
+		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	tx, txResult, err := flow.SendTransaction(
+		context.Background(),
+		*roles,
+		flowkit.Script{Code: code, Args: transactionArgs, Location: location},
+		sendFlags.GasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []string
+	if sendFlags.ExpectStatus != "" || sendFlags.ExpectError != "" || len(sendFlags.ExpectEvents) > 0 {
+		if txResult == nil {
+			return nil, fmt.Errorf("no transaction result to compare with the expected outcome")
+		}
+		failures, err = checkExpectations(txResult, sendFlags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &transactionResult{
+		result:   txResult,
+		tx:       tx,
+		include:  sendFlags.Include,
+		exclude:  sendFlags.Exclude,
+		failures: failures,
+	}, nil
+}
+
+// accountRoles resolves the transaction roles from the account names in the configuration,
+// if no account names are provided the emulator service account is used for all roles.
+func accountRoles(
+	state *flowkit.State,
+	signerName string,
+	proposerName string,
+	payerName string,
+	authorizerNames []string,
+) (*transactions.AccountRoles, error) {
+	var err error
 	var proposer *accounts.Account
 	if proposerName != "" {
 		proposer, err = state.Accounts().ByName(proposerName)
@@ -85,7 +145,6 @@ func SendTransaction(code []byte, args []string, location string, flow flowkit.S
 		}
 	}
 
-	payerName := sendFlags.Payer
 	var payer *accounts.Account
 	if payerName != "" {
 		payer, err = state.Accounts().ByName(payerName)
@@ -95,7 +154,7 @@ func SendTransaction(code []byte, args []string, location string, flow flowkit.S
 	}
 
 	var authorizers []accounts.Account
-	for _, authorizerName := range sendFlags.Authorizers {
+	for _, authorizerName := range authorizerNames {
 		authorizer, err := state.Accounts().ByName(authorizerName)
 		if err != nil {
 			return nil, fmt.Errorf("authorizer account: [%s] doesn't exists in configuration", authorizerName)
@@ -103,8 +162,6 @@ func SendTransaction(code []byte, args []string, location string, flow flowkit.S
 		authorizers = append(authorizers, *authorizer)
 	}
 
-	signerName := sendFlags.Signer
-
 	if signerName == "" {
 		if proposer == nil && payer == nil && len(authorizers) == 0 {
 			signerName = state.Config().Emulators.Default().ServiceAccount
@@ -128,34 +185,9 @@ func SendTransaction(code []byte, args []string, location string, flow flowkit.S
 		authorizers = append(authorizers, *signer)
 	}
 
-	var transactionArgs []cadence.Value
-	if sendFlags.ArgsJSON != "" {
-		transactionArgs, err = arguments.ParseJSON(sendFlags.ArgsJSON)
-	} else {
-		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, location)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
-	}
-
-	tx, txResult, err := flow.SendTransaction(
-		context.Background(),
-		transactions.AccountRoles{
-			Proposer:    *proposer,
-			Authorizers: authorizers,
-			Payer:       *payer,
-		},
-		flowkit.Script{Code: code, Args: transactionArgs, Location: location},
-		sendFlags.GasLimit,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return &transactionResult{
-		result:  txResult,
-		tx:      tx,
-		include: sendFlags.Include,
-		exclude: sendFlags.Exclude,
+	return &transactions.AccountRoles{
+		Proposer:    *proposer,
+		Authorizers: authorizers,
+		Payer:       *payer,
 	}, nil
 }
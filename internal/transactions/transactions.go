@@ -48,13 +48,17 @@ func init() {
 	buildCommand.AddToParent(Cmd)
 	sendSignedCommand.AddToParent(Cmd)
 	decodeCommand.AddToParent(Cmd)
+	sendBatchCommand.AddToParent(Cmd)
+	exportCommand.AddToParent(Cmd)
+	replayCommand.AddToParent(Cmd)
 }
 
 type transactionResult struct {
-	result  *flow.TransactionResult
-	tx      *flow.Transaction
-	include []string
-	exclude []string
+	result   *flow.TransactionResult
+	tx       *flow.Transaction
+	include  []string
+	exclude  []string
+	failures []string
 }
 
 func NewTransactionResult(tx *flow.Transaction, result *flow.TransactionResult) *transactionResult {
@@ -93,6 +97,10 @@ func (r *transactionResult) JSON() any {
 		}
 	}
 
+	if len(r.failures) > 0 {
+		result["expectation_failures"] = r.failures
+	}
+
 	return result
 }
 
@@ -209,10 +217,22 @@ func (r *transactionResult) String() string {
 		_, _ = fmt.Fprint(writer, "\n\nFee Events (hidden, use --include fee-events)")
 	}
 
+	for _, failure := range r.failures {
+		_, _ = fmt.Fprintf(writer, "\n\n%s Expectation failed: %s", output.ErrorEmoji(), failure)
+	}
+
 	_ = writer.Flush()
 	return b.String()
 }
 
+// ExitCode is non-zero when the transaction didn't have the expected outcome.
+func (r *transactionResult) ExitCode() int {
+	if len(r.failures) > 0 {
+		return 1
+	}
+	return 0
+}
+
 func (r *transactionResult) Oneliner() string {
 	result := fmt.Sprintf(
 		"ID: %s, Payer: %s, Authorizer: %s",
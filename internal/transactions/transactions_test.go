@@ -19,19 +19,26 @@
 package transactions
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/flow-emulator/emulator"
 	"github.com/onflow/flow-go-sdk"
+	flowgo "github.com/onflow/flow-go/model/flow"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/onflow/flowkit/v2"
 	"github.com/onflow/flowkit/v2/accounts"
 	"github.com/onflow/flowkit/v2/config"
+	gatewayMocks "github.com/onflow/flowkit/v2/gateway/mocks"
 	"github.com/onflow/flowkit/v2/output"
 	"github.com/onflow/flowkit/v2/tests"
 	"github.com/onflow/flowkit/v2/transactions"
@@ -216,6 +223,278 @@ func Test_Send(t *testing.T) {
 	})
 }
 
+func Test_SendExpectations(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	inArgs := []string{tests.TransactionArgString.Filename, "test"}
+
+	deposit := tests.NewEvent(
+		0,
+		"A.1654653399040a61.FlowToken.TokensDeposited",
+		[]cadence.Field{
+			{Type: cadence.UFix64Type, Identifier: "amount"},
+			{Type: cadence.NewOptionalType(cadence.AddressType), Identifier: "to"},
+		},
+		[]cadence.Value{
+			cadence.UFix64(1000000000),
+			cadence.NewOptional(cadence.NewAddress(flow.HexToAddress("01"))),
+		},
+	)
+	srv.SendTransaction.Return(
+		tests.NewTransaction(),
+		&flow.TransactionResult{Status: flow.TransactionStatusSealed, Events: []flow.Event{*deposit}},
+		nil,
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		flags.ExpectStatus = "sealed"
+		flags.ExpectEvents = []string{"A.1654653399040a61.FlowToken.TokensDeposited(amount=10.00000000", " to=0x01)"}
+
+		result, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.(command.ResultWithExitCode).ExitCode())
+	})
+
+	t.Run("Fail expectations", func(t *testing.T) {
+		flags.ExpectStatus = "executed"
+		flags.ExpectEvents = []string{"A.1654653399040a61.FlowToken.TokensDeposited(amount=5.00000000)", "A.1654653399040a61.FlowToken.TokensWithdrawn"}
+		flags.ExpectError = "panic"
+
+		result, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.(command.ResultWithExitCode).ExitCode())
+
+		failures := result.(*transactionResult).failures
+		assert.Len(t, failures, 4)
+		assert.Equal(t, "expected status:\n- EXECUTED\n+ SEALED\n", failures[0])
+		assert.Equal(t, `expected error containing "panic", but the transaction succeeded`, failures[1])
+		assert.Contains(t, failures[2], "- A.1654653399040a61.FlowToken.TokensDeposited(amount=5.00000000)")
+		assert.Equal(t, "expected event A.1654653399040a61.FlowToken.TokensWithdrawn was not emitted", failures[3])
+	})
+
+	t.Run("Fail invalid matcher", func(t *testing.T) {
+		flags.ExpectEvents = []string{"A.1654653399040a61.FlowToken.TokensDeposited(amount)"}
+
+		_, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid field matcher amount in expected event A.1654653399040a61.FlowToken.TokensDeposited(amount), use name=value")
+	})
+
+	flags.ExpectStatus = ""
+	flags.ExpectEvents = nil
+	flags.ExpectError = ""
+}
+
+func Test_SendBatch(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	gw := gatewayMocks.DefaultMockGateway()
+	srv.Gateway.Return(gw.Mock)
+
+	srv.BuildTransaction.Return(func(
+		_ context.Context,
+		roles transactions.AddressesRoles,
+		keyIndex uint32,
+		script flowkit.Script,
+		_ uint64,
+	) (*transactions.Transaction, error) {
+		tx := transactions.New().SetPayer(roles.Payer)
+		tx.FlowTransaction().SetProposalKey(roles.Proposer, keyIndex, 5)
+		if err := tx.SetScriptWithArgs(script.Code, script.Args); err != nil {
+			return nil, err
+		}
+		return tx.AddAuthorizers(roles.Authorizers)
+	})
+
+	srv.SignTransactionPayload.Return(func(
+		_ context.Context,
+		_ *accounts.Account,
+		payload []byte,
+	) (*transactions.Transaction, error) {
+		return transactions.NewFromPayload(payload)
+	})
+
+	var sequenceNumbers []uint64
+	gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+		tx := args.Get(1).(*flow.Transaction)
+		sequenceNumbers = append(sequenceNumbers, tx.ProposalKey.SequenceNumber)
+		gw.SendSignedTransaction.Return(tx, nil)
+	})
+	gw.GetTransactionResult.Return(&flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil)
+
+	inArgs := []string{tests.TransactionArgString.Filename}
+	_ = rw.WriteFile("rows.csv", []byte("hello\nworld\n# comment\nagain\n"), 0644)
+	sendBatchFlags.Input = "rows.csv"
+	sendBatchFlags.Concurrency = 2
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := sendBatch(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+
+		batch := result.(*batchResult)
+		assert.Len(t, batch.records, 3)
+		assert.Equal(t, 0, batch.ExitCode())
+		for i, record := range batch.records {
+			assert.Equal(t, i+1, record.Row)
+			assert.Equal(t, "SEALED", record.Status)
+		}
+		assert.Equal(t, []uint64{5, 6, 7}, sequenceNumbers)
+
+		results, err := rw.ReadFile("rows.results.jsonl")
+		assert.NoError(t, err)
+		assert.Len(t, strings.Split(strings.TrimSpace(string(results)), "\n"), 6)
+	})
+
+	t.Run("Success resume", func(t *testing.T) {
+		sequenceNumbers = nil
+		_ = rw.WriteFile("rows.csv", []byte("hello\nworld\nagain\nlast\n"), 0644)
+
+		result, err := sendBatch(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.Len(t, result.(*batchResult).records, 4)
+		assert.Equal(t, []uint64{5}, sequenceNumbers)
+	})
+
+	t.Run("Success concurrency limit", func(t *testing.T) {
+		var mu sync.Mutex
+		waiting, maxWaiting := 0, 0
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			mu.Lock()
+			waiting++
+			maxWaiting = max(maxWaiting, waiting)
+			mu.Unlock()
+			gw.SendSignedTransaction.Return(args.Get(1).(*flow.Transaction), nil)
+		})
+		gw.GetTransactionResult.Run(func(mock.Arguments) {
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			waiting--
+			mu.Unlock()
+		})
+
+		_ = rw.WriteFile("many.csv", []byte("1\n2\n3\n4\n5\n6\n7\n8\n"), 0644)
+		sendBatchFlags.Input = "many.csv"
+
+		result, err := sendBatch(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.Len(t, result.(*batchResult).records, 8)
+		assert.Equal(t, 2, maxWaiting)
+		sendBatchFlags.Input = "rows.csv"
+	})
+
+	t.Run("Fail parsing row", func(t *testing.T) {
+		_ = rw.WriteFile("rows.jsonl", []byte(`[{"type":"String","value":"hello"}]`+"\ninvalid\n"), 0644)
+		sendBatchFlags.Input = "rows.jsonl"
+		sendBatchFlags.Results = "invalid.results.jsonl"
+
+		result, err := sendBatch(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+
+		batch := result.(*batchResult)
+		assert.Equal(t, 1, batch.ExitCode())
+		assert.Len(t, batch.failed(), 1)
+		assert.Equal(t, 2, batch.failed()[0].Row)
+		sendBatchFlags.Input = "rows.csv"
+		sendBatchFlags.Results = ""
+	})
+
+	t.Run("Fail missing input", func(t *testing.T) {
+		sendBatchFlags.Input = ""
+		_, err := sendBatch(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "input file is required, use --input flag")
+		sendBatchFlags.Input = "rows.csv"
+	})
+}
+
+func Test_Export(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	srv.Mock.On("ReplaceImportsInScript", mock.Anything, mock.Anything).Return(
+		flowkit.Script{Code: tests.TransactionArgString.Source},
+		nil,
+	)
+	inArgs := []string{tests.TransactionArgString.Filename, "test"}
+
+	t.Run("Success FCL", func(t *testing.T) {
+		exportFlags.Lang = "js"
+
+		result, err := export(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+
+		code := result.String()
+		assert.Contains(t, code, `.put("accessNode.api", "http://127.0.0.1:8888")`)
+		assert.Contains(t, code, `arg("test", t.String)`)
+		assert.Contains(t, code, "EMULATOR_ACCOUNT_PRIVATE_KEY")
+		assert.Contains(t, code, "proposer: emulatorAccount")
+	})
+
+	t.Run("Success Go", func(t *testing.T) {
+		exportFlags.Lang = "go"
+
+		result, err := export(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+
+		code := result.String()
+		assert.Contains(t, code, `grpc.NewClient("127.0.0.1:3569")`)
+		assert.Contains(t, code, `AddRawArgument([]byte(`+"`"+`{"value":"test","type":"String"}`+"`"+`))`)
+		assert.Contains(t, code, `os.Getenv("EMULATOR_ACCOUNT_PRIVATE_KEY")`)
+		assert.Contains(t, code, "SetProposalKey(proposer.Address, 0, accountKey(proposer, 0).SequenceNumber)")
+	})
+
+	t.Run("Fail unsupported language", func(t *testing.T) {
+		exportFlags.Lang = "rust"
+
+		_, err := export(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "unsupported language rust, use js or go")
+	})
+
+	exportFlags.Lang = "js"
+}
+
+func Test_Replay(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	inArgs := []string{"0x0000000000000000000000000000000000000000000000000000000000000001"}
+
+	t.Run("Success", func(t *testing.T) {
+		serviceAddress := flow.Address(flowgo.Emulator.Chain().ServiceAddress())
+		tx := flow.NewTransaction().
+			SetScript([]byte(`transaction { prepare(signer: &Account) { log("replayed") } execute { panic("failed") } }`)).
+			SetProposalKey(serviceAddress, 0, 0).
+			SetPayer(serviceAddress).
+			AddAuthorizer(serviceAddress)
+		srv.GetTransactionByID.Return(tx, &flow.TransactionResult{BlockHeight: 10}, nil)
+
+		defaultExecutor := newReplayExecutor
+		defer func() { newReplayExecutor = defaultExecutor }()
+		closed := false
+		newReplayExecutor = func(_ config.Network, height uint64, _ zerolog.Logger) (replayExecutor, func(), error) {
+			assert.Equal(t, uint64(9), height)
+			blockchain, err := emulator.New(emulator.WithTransactionValidationEnabled(false))
+			return blockchain, func() { closed = true }, err
+		}
+
+		result, err := replay(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+
+		replayed := result.(*replayResult)
+		assert.Equal(t, []string{`"replayed"`}, replayed.result.Logs)
+		assert.ErrorContains(t, replayed.result.Error, "failed")
+		assert.Contains(t, result.String(), "Reverted")
+		assert.True(t, closed)
+	})
+
+	t.Run("Fail not executed", func(t *testing.T) {
+		srv.GetTransactionByID.Return(tests.NewTransaction(), &flow.TransactionResult{}, nil)
+
+		_, err := replay(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "transaction 0000000000000000000000000000000000000000000000000000000000000001 was not executed yet, provide the height with --height")
+	})
+
+	t.Run("Fail unsupported network", func(t *testing.T) {
+		srv.GetTransactionByID.Return(tests.NewTransaction(), &flow.TransactionResult{BlockHeight: 10}, nil)
+
+		_, err := replay(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "transaction replay is only supported on mainnet and testnet networks")
+	})
+}
+
 func Test_SendSigned(t *testing.T) {
 	srv, _, rw := util.TestMocks(t)
 
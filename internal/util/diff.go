@@ -0,0 +1,218 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// LineDiff returns a line based diff of the two texts, where lines only present
+// in the first text are prefixed with "-" and lines only present in the second with "+".
+func LineDiff(from string, to string) string {
+	var b strings.Builder
+	for _, diff := range lineDiffs(from, to) {
+		prefix := "  "
+		switch diff.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "- "
+		case diffmatchpatch.DiffInsert:
+			prefix = "+ "
+		}
+
+		for _, line := range strings.SplitAfter(diff.Text, "\n") {
+			if line == "" {
+				continue
+			}
+			b.WriteString(prefix)
+			b.WriteString(strings.TrimSuffix(line, "\n"))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// PrettyDiff returns a character based diff of the two texts colored for the terminal.
+func PrettyDiff(from string, to string) string {
+	dmp := diffmatchpatch.New()
+	return dmp.DiffPrettyText(dmp.DiffMain(from, to, false))
+}
+
+// unifiedContext is the number of unchanged lines shown around the changes in a unified diff.
+const unifiedContext = 3
+
+// UnifiedDiff returns the differences of the two texts in the unified diff format,
+// or an empty string if the texts are the same.
+//
+// Like the patches of diffmatchpatch, changes separated by only a few unchanged lines are joined into the same hunk.
+func UnifiedDiff(fromName string, toName string, from string, to string) string {
+	diffs := lineDiffs(from, to)
+
+	var b strings.Builder
+	var hunk *unifiedHunk
+	fromLine, toLine := 0, 0 // number of lines of each text before the current diff
+	for i, diff := range diffs {
+		lines := splitLines(diff.Text)
+
+		if diff.Type == diffmatchpatch.DiffEqual {
+			if hunk != nil {
+				if len(lines) <= 2*unifiedContext && i != len(diffs)-1 {
+					hunk.add(" ", lines)
+				} else {
+					hunk.add(" ", lines[:min(unifiedContext, len(lines))])
+					hunk.write(&b, fromName, toName)
+					hunk = nil
+				}
+			}
+
+			fromLine += len(lines)
+			toLine += len(lines)
+			continue
+		}
+
+		if hunk == nil {
+			var leading []string
+			if i > 0 {
+				previous := splitLines(diffs[i-1].Text)
+				leading = previous[max(len(previous)-unifiedContext, 0):]
+			}
+			hunk = &unifiedHunk{fromStart: fromLine - len(leading), toStart: toLine - len(leading)}
+			hunk.add(" ", leading)
+		}
+
+		if diff.Type == diffmatchpatch.DiffDelete {
+			hunk.add("-", lines)
+			fromLine += len(lines)
+		} else {
+			hunk.add("+", lines)
+			toLine += len(lines)
+		}
+	}
+
+	if hunk != nil {
+		hunk.write(&b, fromName, toName)
+	}
+
+	return b.String()
+}
+
+// unifiedHunk is a group of changed lines with their unchanged context lines.
+type unifiedHunk struct {
+	fromStart int
+	toStart   int
+	fromCount int
+	toCount   int
+	lines     []string
+}
+
+func (h *unifiedHunk) add(prefix string, lines []string) {
+	for _, line := range lines {
+		if prefix != "+" {
+			h.fromCount++
+		}
+		if prefix != "-" {
+			h.toCount++
+		}
+		h.lines = append(h.lines, prefix+line)
+	}
+}
+
+// write appends the hunk to the diff, starting with the file names if it's the first hunk.
+func (h *unifiedHunk) write(b *strings.Builder, fromName string, toName string) {
+	if b.Len() == 0 {
+		_, _ = fmt.Fprintf(b, "--- %s\n+++ %s\n", fromName, toName)
+	}
+	_, _ = fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(h.fromStart, h.fromCount), hunkRange(h.toStart, h.toCount))
+	for _, line := range h.lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+}
+
+// hunkRange formats the start line and the number of lines of a hunk, the start is the
+// line before the hunk if it's empty.
+func hunkRange(before int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// lineDiffs returns the line based diffs of the two texts.
+//
+// The lines are encoded as runes before being diffed, because the line mode of diffmatchpatch
+// can split the encoded line indexes and mix up the lines.
+func lineDiffs(from string, to string) []diffmatchpatch.Diff {
+	lineArray := make([]string, 0)
+	lineRunes := make(map[string]rune)
+	encode := func(text string) []rune {
+		runes := make([]rune, 0)
+		for _, line := range strings.SplitAfter(text, "\n") {
+			if line == "" {
+				continue
+			}
+			r, ok := lineRunes[line]
+			if !ok {
+				r = rune(len(lineArray))
+				if r >= 0xD800 {
+					// surrogates are not valid runes and don't survive the conversion to strings
+					r += 0x800
+				}
+				lineRunes[line] = r
+				lineArray = append(lineArray, line)
+			}
+			runes = append(runes, r)
+		}
+		return runes
+	}
+	fromRunes := encode(from)
+	toRunes := encode(to)
+
+	diffs := diffmatchpatch.New().DiffMainRunes(fromRunes, toRunes, false)
+	for i, diff := range diffs {
+		var text strings.Builder
+		for _, r := range diff.Text {
+			if r >= 0xD800+0x800 {
+				r -= 0x800
+			}
+			text.WriteString(lineArray[r])
+		}
+		diffs[i].Text = text.String()
+	}
+
+	return diffs
+}
+
+// splitLines splits the text into its lines without the line endings.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\n")
+	}
+	return lines
+}
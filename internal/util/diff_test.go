@@ -0,0 +1,65 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnifiedDiff(t *testing.T) {
+	lines := make([]string, 0, 20)
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	from := strings.Join(lines, "\n") + "\n"
+
+	assert.Equal(t, "", UnifiedDiff("a", "b", from, from))
+
+	to := strings.Replace(from, "line 2\n", "line two\n", 1)
+	to = strings.Replace(to, "line 8\n", "", 1)
+	to = strings.Replace(to, "line 18\n", "line 18\nline 18.5\n", 1)
+
+	assert.Equal(t, `--- a
++++ b
+@@ -1,11 +1,10 @@
+ line 1
+-line 2
++line two
+ line 3
+ line 4
+ line 5
+ line 6
+ line 7
+-line 8
+ line 9
+ line 10
+ line 11
+@@ -16,5 +15,6 @@
+ line 16
+ line 17
+ line 18
++line 18.5
+ line 19
+ line 20
+`, UnifiedDiff("a", "b", from, to))
+}
@@ -19,9 +19,18 @@
 package util
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"path/filepath"
 	"strings"
+	"text/template"
+
+	"github.com/spf13/afero"
+
+	"github.com/onflow/flowkit/v2"
 )
 
 func AddCDCExtension(name string) string {
@@ -34,3 +43,48 @@ func AddCDCExtension(name string) string {
 func StripCDCExtension(name string) string {
 	return strings.TrimSuffix(name, filepath.Ext(name))
 }
+
+// ProcessTemplate reads a template file from the filesystem and processes it with the provided data
+// If you don't need to provide data, pass nil
+func ProcessTemplate(templates fs.FS, templatePath string, data any) (string, error) {
+	templateData, err := fs.ReadFile(templates, templatePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	tmpl, err := template.New("template").Parse(string(templateData))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var executedTemplate bytes.Buffer
+	// Execute the template with the provided data or nil if no data is needed
+	if err = tmpl.Execute(&executedTemplate, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return executedTemplate.String(), nil
+}
+
+// FileOpener is implemented by reader writers able to open files for streaming, like afero.Afero.
+type FileOpener interface {
+	Open(name string) (afero.File, error)
+}
+
+// OpenFile opens the file with the reader writer, the file is streamed if the reader writer
+// implements FileOpener and read in memory otherwise.
+func OpenFile(rw flowkit.ReaderWriter, filename string) (io.ReadCloser, error) {
+	if opener, ok := rw.(FileOpener); ok {
+		file, err := opener.Open(filename)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return file, err
+		}
+	}
+
+	content, err := rw.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
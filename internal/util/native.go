@@ -0,0 +1,160 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+// NativeValue converts a Cadence value into a value that encodes to idiomatic JSON.
+//
+// Composites become objects keyed by field name, optionals become null or the inner value, dictionaries with
+// simple keys become objects and otherwise a list of key and value pairs. Integers are encoded as JSON numbers
+// with all their digits, while fixed-point numbers are encoded as strings to keep their precision.
+func NativeValue(value cadence.Value) any {
+	switch v := value.(type) {
+	case nil, cadence.Void:
+		return nil
+	case cadence.Optional:
+		return NativeValue(v.Value)
+	case cadence.Bool:
+		return bool(v)
+	case cadence.String:
+		return string(v)
+	case cadence.Character:
+		return string(v)
+	case cadence.Address:
+		return fmt.Sprintf("0x%s", v.Hex())
+	case cadence.Fix64, cadence.UFix64:
+		return v.String()
+	case cadence.NumberValue:
+		return json.Number(v.String())
+	case cadence.Array:
+		values := make([]any, 0, len(v.Values))
+		for _, element := range v.Values {
+			values = append(values, NativeValue(element))
+		}
+		return values
+	case cadence.Dictionary:
+		return nativeDictionary(v)
+	case cadence.Composite:
+		fields := make(map[string]any)
+		for name, field := range cadence.FieldsMappedByName(v) {
+			fields[name] = NativeValue(field)
+		}
+		return fields
+	case cadence.Path:
+		return v.String()
+	case cadence.TypeValue:
+		if v.StaticType == nil {
+			return nil
+		}
+		return v.StaticType.ID()
+	case cadence.Capability:
+		capability := map[string]any{
+			"id":      json.Number(v.ID.String()),
+			"address": fmt.Sprintf("0x%s", v.Address.Hex()),
+		}
+		if v.BorrowType != nil {
+			capability["borrowType"] = v.BorrowType.ID()
+		}
+		return capability
+	case *cadence.InclusiveRange:
+		return map[string]any{
+			"start": NativeValue(v.Start),
+			"end":   NativeValue(v.End),
+			"step":  NativeValue(v.Step),
+		}
+	default:
+		return value.String()
+	}
+}
+
+// nativeDictionary converts the dictionary into an object if all the keys can be used as object keys,
+// otherwise into a list of key and value pairs.
+func nativeDictionary(dictionary cadence.Dictionary) any {
+	object := make(map[string]any, len(dictionary.Pairs))
+	for _, pair := range dictionary.Pairs {
+		var key string
+		switch k := pair.Key.(type) {
+		case cadence.String:
+			key = string(k)
+		case cadence.Character:
+			key = string(k)
+		case cadence.Address:
+			key = fmt.Sprintf("0x%s", k.Hex())
+		case cadence.NumberValue, cadence.Bool, cadence.Path:
+			key = k.String()
+		default:
+			return nativePairs(dictionary)
+		}
+		object[key] = NativeValue(pair.Value)
+	}
+
+	return object
+}
+
+func nativePairs(dictionary cadence.Dictionary) []any {
+	pairs := make([]any, 0, len(dictionary.Pairs))
+	for _, pair := range dictionary.Pairs {
+		pairs = append(pairs, map[string]any{
+			"key":   NativeValue(pair.Key),
+			"value": NativeValue(pair.Value),
+		})
+	}
+	return pairs
+}
+
+// NativeJSON replaces all the JSON-Cadence encoded values in the JSON result with their native JSON representation.
+//
+// Values that are not valid JSON-Cadence are left unchanged.
+func NativeJSON(result any) any {
+	switch v := result.(type) {
+	case json.RawMessage:
+		value, err := jsoncdc.Decode(nil, v)
+		if err != nil {
+			return v
+		}
+		return NativeValue(value)
+	case map[string]any:
+		converted := make(map[string]any, len(v))
+		for key, value := range v {
+			converted[key] = NativeJSON(value)
+		}
+		return converted
+	case []map[string]any:
+		converted := make([]any, 0, len(v))
+		for _, value := range v {
+			converted = append(converted, NativeJSON(value))
+		}
+		return converted
+	case []any:
+		converted := make([]any, 0, len(v))
+		for _, value := range v {
+			converted = append(converted, NativeJSON(value))
+		}
+		return converted
+	default:
+		return result
+	}
+}
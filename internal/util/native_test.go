@@ -0,0 +1,64 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NativeJSON(t *testing.T) {
+	structType := cadence.NewStructType(
+		nil,
+		"S.test.Info",
+		[]cadence.Field{
+			{Identifier: "balance", Type: cadence.UFix64Type},
+			{Identifier: "owner", Type: cadence.NewOptionalType(cadence.AddressType)},
+			{Identifier: "tags", Type: cadence.NewDictionaryType(cadence.StringType, cadence.IntType)},
+			{Identifier: "ids", Type: cadence.NewVariableSizedArrayType(cadence.UInt64Type)},
+		},
+		nil,
+	)
+	value := cadence.NewStruct([]cadence.Value{
+		cadence.UFix64(1050000000),
+		cadence.NewOptional(nil),
+		cadence.NewDictionary([]cadence.KeyValuePair{{Key: cadence.String("a"), Value: cadence.NewInt(1)}}),
+		cadence.NewArray([]cadence.Value{cadence.UInt64(18446744073709551615)}),
+	}).WithType(structType)
+
+	assert.Equal(t, map[string]any{
+		"balance": "10.50000000",
+		"owner":   nil,
+		"tags":    map[string]any{"a": json.Number("1")},
+		"ids":     []any{json.Number("18446744073709551615")},
+	}, NativeJSON(json.RawMessage(jsoncdc.MustEncode(value))))
+
+	pairs := cadence.NewDictionary([]cadence.KeyValuePair{{
+		Key:   cadence.NewArray([]cadence.Value{cadence.NewInt(1)}),
+		Value: cadence.String("one"),
+	}})
+	assert.Equal(t, []any{map[string]any{
+		"key":   []any{json.Number("1")},
+		"value": "one",
+	}}, NativeValue(pairs))
+}
@@ -27,6 +27,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"github.com/onflow/flow-go-sdk"
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -43,13 +44,22 @@ func Exit(code int, msg string) {
 	os.Exit(code)
 }
 
+// gitIgnorePath returns the path of the .gitignore in the working directory.
+func gitIgnorePath() (string, error) {
+	currentWd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(currentWd, ".gitignore"), nil
+}
+
 // AddToGitIgnore adds a new line to the .gitignore if one doesn't exist it creates it.
 func AddToGitIgnore(filename string, loader flowkit.ReaderWriter) error {
-	currentWd, err := os.Getwd()
+	gitIgnorePath, err := gitIgnorePath()
 	if err != nil {
 		return err
 	}
-	gitIgnorePath := filepath.Join(currentWd, ".gitignore")
 	gitIgnoreFiles := ""
 	filePermissions := os.FileMode(0644)
 
@@ -69,6 +79,42 @@ func AddToGitIgnore(filename string, loader flowkit.ReaderWriter) error {
 	)
 }
 
+// IsGitIgnored checks if the file is matched by the patterns in the .gitignore of the directory,
+// relative file names are relative to the directory.
+func IsGitIgnored(filename string, dir string, loader flowkit.ReaderWriter) (bool, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false, err
+	}
+
+	content, err := loader.ReadFile(filepath.Join(dir, ".gitignore"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	patterns := make([]gitignore.Pattern, 0)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
+	}
+
+	if filepath.IsAbs(filename) {
+		filename, err = filepath.Rel(dir, filename)
+		if err != nil {
+			return false, err
+		}
+	}
+	path := strings.Split(filepath.ToSlash(filepath.Clean(filename)), "/")
+
+	return gitignore.NewMatcher(patterns).Match(path, false), nil
+}
+
 // GetAddressNetwork returns the chain ID for an address.
 func GetAddressNetwork(address flowsdk.Address) (flowsdk.ChainID, error) {
 	networks := []flowsdk.ChainID{
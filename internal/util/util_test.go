@@ -0,0 +1,128 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flowkit/v2"
+	"github.com/onflow/flowkit/v2/tests"
+)
+
+func Test_IsGitIgnored(t *testing.T) {
+	rw, _ := tests.ReaderWriter()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	t.Run("Missing gitignore", func(t *testing.T) {
+		ignored, err := IsGitIgnored("alice.pkey", ".", rw)
+		require.NoError(t, err)
+		assert.False(t, ignored)
+	})
+
+	require.NoError(t, rw.WriteFile(
+		filepath.Join(wd, ".gitignore"),
+		[]byte("# keys\n*.pkey\n\nkeys/\n/emulator.key\n"),
+		0644,
+	))
+
+	files := map[string]bool{
+		"alice.pkey":                      true,
+		"project/bob.pkey":                true,
+		"./project/../alice.pkey":         true,
+		"keys/testnet.key":                true,
+		"emulator.key":                    true,
+		"project/emulator.key":            false,
+		"flow.json":                       false,
+		filepath.Join(wd, "alice.pkey"):   true,
+		filepath.Join(wd, "testnet.json"): false,
+	}
+
+	for filename, expected := range files {
+		ignored, err := IsGitIgnored(filename, ".", rw)
+		require.NoError(t, err)
+		assert.Equal(t, expected, ignored, filename)
+	}
+
+	t.Run("Directory", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile(filepath.Join(wd, "project", ".gitignore"), []byte("/testnet.json\n"), 0644))
+
+		files := map[string]bool{
+			"testnet.json": true,
+			"alice.pkey":   false,
+			filepath.Join(wd, "project", "testnet.json"): true,
+			filepath.Join(wd, "testnet.json"):            false,
+		}
+
+		for filename, expected := range files {
+			ignored, err := IsGitIgnored(filename, "project", rw)
+			require.NoError(t, err)
+			assert.Equal(t, expected, ignored, filename)
+		}
+	})
+}
+
+// readerWriter hides the Open method of the wrapped reader writer.
+type readerWriter struct {
+	flowkit.ReaderWriter
+}
+
+// unsupportedOpener reports opening files as unsupported, like a wrapper of a reader writer without Open.
+type unsupportedOpener struct {
+	flowkit.ReaderWriter
+}
+
+func (rw *unsupportedOpener) Open(name string) (afero.File, error) {
+	return nil, &os.PathError{Op: "open", Path: name, Err: errors.ErrUnsupported}
+}
+
+func Test_OpenFile(t *testing.T) {
+	rw, _ := tests.ReaderWriter()
+	require.NoError(t, rw.WriteFile("release.tar.gz", []byte("release"), 0644))
+
+	readerWriters := map[string]flowkit.ReaderWriter{
+		"streamed":    rw,
+		"in memory":   &readerWriter{ReaderWriter: rw},
+		"unsupported": &unsupportedOpener{ReaderWriter: rw},
+	}
+
+	for name, readerWriter := range readerWriters {
+		t.Run(name, func(t *testing.T) {
+			file, err := OpenFile(readerWriter, "release.tar.gz")
+			require.NoError(t, err)
+			defer file.Close()
+
+			content, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, "release", string(content))
+
+			_, err = OpenFile(readerWriter, "missing.tar.gz")
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
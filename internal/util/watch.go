@@ -0,0 +1,81 @@
+/*
+ * Flow CLI
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"time"
+
+	"github.com/radovskyb/watcher"
+)
+
+// watchPollInterval is how often watched files are checked for changes.
+const watchPollInterval = 500 * time.Millisecond
+
+// FileWatcher reports changes of watched files and folders, it is shared by the commands
+// reacting to changes of project files.
+type FileWatcher struct {
+	watcher *watcher.Watcher
+}
+
+// NewFileWatcher creates a watcher reporting writes, creations, renames and removals.
+func NewFileWatcher() *FileWatcher {
+	w := watcher.New()
+	w.FilterOps(watcher.Write, watcher.Create, watcher.Rename, watcher.Remove)
+
+	return &FileWatcher{watcher: w}
+}
+
+// Add watches the file or the folder without its subfolders.
+func (w *FileWatcher) Add(path string) error {
+	return w.watcher.Add(path)
+}
+
+// AddRecursive watches the folder including all its subfolders.
+func (w *FileWatcher) AddRecursive(path string) error {
+	return w.watcher.AddRecursive(path)
+}
+
+// Start polls the watched files for changes in the background, errors are reported on the errors channel.
+func (w *FileWatcher) Start() {
+	go func() {
+		if err := w.watcher.Start(watchPollInterval); err != nil {
+			w.watcher.Error <- err
+		}
+	}()
+}
+
+// Events returns the channel changes are reported on.
+func (w *FileWatcher) Events() <-chan watcher.Event {
+	return w.watcher.Event
+}
+
+// Errors returns the channel watching errors are reported on.
+func (w *FileWatcher) Errors() <-chan error {
+	return w.watcher.Error
+}
+
+// Closed returns the channel that is notified when the watcher is closed.
+func (w *FileWatcher) Closed() <-chan struct{} {
+	return w.watcher.Closed
+}
+
+// Close stops watching the files.
+func (w *FileWatcher) Close() {
+	w.watcher.Close()
+}